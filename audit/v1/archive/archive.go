@@ -0,0 +1,369 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+// Package archive keeps a local copy of Hermes events for long-term
+// retention. Events are pulled with events.List and written into
+// gzip-compressed JSON Lines files that are partitioned by day:
+//
+//	<dir>/2026/10/19/events-0000.jsonl.gz
+//	<dir>/2026/10/19/events-0001.jsonl.gz
+//	<dir>/ids/3f.json
+//	<dir>/index.json
+//
+// The ID index maps event IDs to the files that contain them. It is sharded
+// into 256 files by a hash of the ID, so that skipping events that are
+// already archived and looking up an event by ID only read one small file.
+// The index maps target IDs and initiator IDs to the files that contain the
+// respective events, so that lookups do not need to read the whole archive.
+// Both are written once at the end of each Sync. Offline queries use the same
+// events.ListOpts as List.
+package archive
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/pagination"
+
+	"github.com/sapcc/gophercloud-sapcc/v2/audit/v1/events"
+)
+
+// DefaultMaxFileSize is the size in bytes after which a new file is started
+// for a day, when Opts.MaxFileSize is not set.
+const DefaultMaxFileSize = 64 << 20
+
+const (
+	indexFileName = "index.json"
+	idsDirName    = "ids"
+)
+
+// ErrNotFound is returned by Archive.Get when an event is not archived.
+var ErrNotFound = errors.New("event not found in archive")
+
+// Opts configures an Archive.
+type Opts struct {
+	// Dir is the directory that holds the archive. It is created if it does
+	// not exist.
+	Dir string
+	// MaxFileSize is the size in bytes after which a new file is started
+	// for a day. Files can grow slightly larger since a file is only rotated
+	// after a batch of events has been written to it.
+	MaxFileSize int64
+}
+
+// Archive is a local, date-partitioned archive of Hermes events. It is safe
+// for concurrent use within one process, but the archive directory must not
+// be shared between processes.
+type Archive struct {
+	dir         string
+	maxFileSize int64
+
+	mutex sync.Mutex
+	index index
+	// pending holds the shards of the ID index that were written to since
+	// the last call of flush.
+	pending map[string]idShard
+}
+
+// Open opens the archive in opts.Dir, or creates an empty one.
+func Open(opts Opts) (*Archive, error) {
+	if opts.Dir == "" {
+		return nil, errors.New("option Dir is required")
+	}
+	err := os.MkdirAll(opts.Dir, 0o750)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &Archive{
+		dir:         opts.Dir,
+		maxFileSize: opts.MaxFileSize,
+		index:       newIndex(),
+		pending:     make(map[string]idShard),
+	}
+	if a.maxFileSize <= 0 {
+		a.maxFileSize = DefaultMaxFileSize
+	}
+
+	buf, err := os.ReadFile(filepath.Join(a.dir, indexFileName))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return a, nil
+	case err != nil:
+		return nil, err
+	}
+	err = json.Unmarshal(buf, &a.index)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse archive index: %w", err)
+	}
+	a.index.init()
+	return a, nil
+}
+
+// Cursor returns the event time of the newest archived event, or the zero
+// time if the archive is empty.
+func (a *Archive) Cursor() time.Time {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	return a.index.Cursor
+}
+
+// Sync pulls all events that are newer than the archive's cursor and
+// appends them to the archive. The filters in opts restrict which events are
+// archived; its Time, Sort and Offset fields are replaced so that events are
+// pulled in chronological order starting at the cursor. Events that are
+// already archived are skipped. The index is written once when Sync returns,
+// also when pulling events failed. Sync returns the number of newly archived
+// events.
+func (a *Archive) Sync(ctx context.Context, client *gophercloud.ServiceClient, opts events.ListOpts) (int, error) {
+	opts.Sort = fmt.Sprintf("%s:%s", events.SortKeyTime, events.SortAsc)
+	opts.Offset = 0
+	opts.Time = nil
+	if cursor := a.Cursor(); !cursor.IsZero() {
+		opts.Time = []events.DateQuery{{Date: cursor, Filter: events.DateFilterGTE}}
+	}
+
+	count := 0
+	err := events.List(client, opts).EachPage(ctx, func(_ context.Context, page pagination.Page) (bool, error) {
+		evs, err := events.ExtractEvents(page)
+		if err != nil {
+			return false, err
+		}
+		n, err := a.append(evs)
+		count += n
+		return err == nil, err
+	})
+	return count, errors.Join(err, a.flush())
+}
+
+// Run calls Sync every interval until the context is cancelled. It returns
+// the first error returned by Sync, after which Run can be called again to
+// resume from the archive's cursor.
+func (a *Archive) Run(ctx context.Context, client *gophercloud.ServiceClient, opts events.ListOpts, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		_, err := a.Sync(ctx, client, opts)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// append writes the given events to the archive. The updated index is only
+// kept in memory until flush is called.
+func (a *Archive) append(evs []events.Event) (int, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	// group new events by day, keeping their order
+	byDay := make(map[string][]events.Event)
+	shards := make(map[string]idShard)
+	times := make(map[string]time.Time, len(evs))
+	var days []string
+	for _, ev := range evs {
+		t, err := eventTime(ev)
+		if err != nil {
+			return 0, fmt.Errorf("event %s: %w", ev.ID, err)
+		}
+		name := shardName(ev.ID)
+		if _, exists := shards[name]; !exists {
+			shards[name], err = a.shard(name)
+			if err != nil {
+				return 0, err
+			}
+		}
+		if _, exists := shards[name][ev.ID]; exists {
+			continue
+		}
+		day := t.UTC().Format(dayLayout)
+		if _, exists := times[ev.ID]; exists {
+			continue
+		}
+		times[ev.ID] = t
+		if _, exists := byDay[day]; !exists {
+			days = append(days, day)
+		}
+		byDay[day] = append(byDay[day], ev)
+	}
+
+	count := 0
+	for _, day := range days {
+		file, err := a.writeDay(day, byDay[day])
+		if err != nil {
+			return count, err
+		}
+		for _, ev := range byDay[day] {
+			name := shardName(ev.ID)
+			shards[name][ev.ID] = file
+			a.pending[name] = shards[name]
+			a.index.add(ev, file)
+			if times[ev.ID].After(a.index.Cursor) {
+				a.index.Cursor = times[ev.ID]
+			}
+		}
+		count += len(byDay[day])
+	}
+	return count, nil
+}
+
+// shard returns the shard of the ID index with the given name, preferring
+// the pending version over the one on disk. The caller must hold the mutex.
+func (a *Archive) shard(name string) (idShard, error) {
+	if shard, exists := a.pending[name]; exists {
+		return shard, nil
+	}
+	return a.readShard(name)
+}
+
+// readShard reads the shard of the ID index with the given name from disk.
+// Since shards are replaced atomically, it does not need the mutex.
+func (a *Archive) readShard(name string) (idShard, error) {
+	shard := make(idShard)
+	buf, err := os.ReadFile(a.shardPath(name))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return shard, nil
+	case err != nil:
+		return nil, err
+	}
+	err = json.Unmarshal(buf, &shard)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse event IDs in shard %s: %w", name, err)
+	}
+	return shard, nil
+}
+
+func (a *Archive) shardPath(name string) string {
+	return filepath.Join(a.dir, idsDirName, name+".json")
+}
+
+// writeDay appends the events to the current file of the given day as a new
+// gzip member, and returns the path of that file relative to the archive.
+// Readers of the gzip format treat concatenated members as one stream.
+func (a *Archive) writeDay(day string, evs []events.Event) (string, error) {
+	part := a.index.Parts[day]
+	file := partFile(day, part)
+	path := filepath.Join(a.dir, filepath.FromSlash(file))
+
+	info, err := os.Stat(path)
+	if err == nil && info.Size() >= a.maxFileSize {
+		part++
+		file = partFile(day, part)
+		path = filepath.Join(a.dir, filepath.FromSlash(file))
+	}
+	a.index.Parts[day] = part
+
+	err = os.MkdirAll(filepath.Dir(path), 0o750)
+	if err != nil {
+		return "", err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o640)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	enc := json.NewEncoder(gz)
+	for _, ev := range evs {
+		err = enc.Encode(ev)
+		if err != nil {
+			return "", err
+		}
+	}
+	err = gz.Close()
+	if err != nil {
+		return "", err
+	}
+	return file, f.Close()
+}
+
+// flush writes the shards of the ID index that were changed and the index. The
+// event files are written before, so an interrupted Sync at most leaves
+// events in the files that are not in the index yet. They are pulled and
+// written again by the next Sync, and Query skips the duplicates.
+func (a *Archive) flush() error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if len(a.pending) == 0 {
+		return nil
+	}
+
+	err := os.MkdirAll(filepath.Join(a.dir, idsDirName), 0o750)
+	if err != nil {
+		return err
+	}
+	for name, shard := range a.pending {
+		err = writeJSON(a.shardPath(name), shard)
+		if err != nil {
+			return err
+		}
+	}
+	err = writeJSON(filepath.Join(a.dir, indexFileName), a.index)
+	if err != nil {
+		return err
+	}
+	clear(a.pending)
+	return nil
+}
+
+// writeJSON writes the file atomically by renaming a temporary file over it.
+func writeJSON(path string, data any) error {
+	buf, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	err = os.WriteFile(tmp, buf, 0o640)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+const dayLayout = "2006/01/02"
+
+func partFile(day string, part int) string {
+	return fmt.Sprintf("%s/events-%04d.jsonl.gz", day, part)
+}
+
+// files returns all archive files of the days in [from, to], in
+// chronological order. Zero times leave the respective side open.
+func (a *Archive) files(from, to time.Time) []string {
+	var result []string
+	for day, lastPart := range a.index.Parts {
+		if !from.IsZero() && day < from.UTC().Format(dayLayout) {
+			continue
+		}
+		if !to.IsZero() && day > to.UTC().Format(dayLayout) {
+			continue
+		}
+		for part := range lastPart + 1 {
+			result = append(result, partFile(day, part))
+		}
+	}
+	slices.Sort(result)
+	return result
+}
+
+func eventTime(ev events.Event) (time.Time, error) {
+	return time.Parse(time.RFC3339Nano, ev.EventTime)
+}
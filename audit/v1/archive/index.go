@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package archive
+
+import (
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"time"
+
+	"github.com/sapcc/gophercloud-sapcc/v2/audit/v1/events"
+)
+
+// index is the on-disk index of an Archive. All file paths are relative to
+// the archive directory and use forward slashes.
+type index struct {
+	// Cursor is the event time of the newest archived event.
+	Cursor time.Time `json:"cursor"`
+	// Parts maps days to the number of their newest file.
+	Parts map[string]int `json:"parts"`
+	// Targets and Initiators map resource IDs to the files that contain
+	// events with that target or initiator.
+	Targets    map[string][]string `json:"targets"`
+	Initiators map[string][]string `json:"initiators"`
+}
+
+// idShard is one shard of the ID index. It maps event IDs to the file that
+// contains the event.
+type idShard map[string]string
+
+// shardName returns the name of the shard of the ID index that holds the
+// given event ID.
+func shardName(id string) string {
+	h := fnv.New32a()
+	h.Write([]byte(id)) //nolint:errcheck // hash.Hash.Write never fails
+	return fmt.Sprintf("%02x", h.Sum32()%256)
+}
+
+func newIndex() index {
+	var idx index
+	idx.init()
+	return idx
+}
+
+// init allocates the maps that are missing in an index read from disk.
+func (idx *index) init() {
+	if idx.Parts == nil {
+		idx.Parts = make(map[string]int)
+	}
+	if idx.Targets == nil {
+		idx.Targets = make(map[string][]string)
+	}
+	if idx.Initiators == nil {
+		idx.Initiators = make(map[string][]string)
+	}
+}
+
+func (idx *index) add(ev events.Event, file string) {
+	if ev.Target.ID != "" {
+		idx.Targets[ev.Target.ID] = addFile(idx.Targets[ev.Target.ID], file)
+	}
+	if ev.Initiator.ID != "" {
+		idx.Initiators[ev.Initiator.ID] = addFile(idx.Initiators[ev.Initiator.ID], file)
+	}
+}
+
+// addFile inserts a file into a sorted list of files.
+func addFile(files []string, file string) []string {
+	pos, found := slices.BinarySearch(files, file)
+	if found {
+		return files
+	}
+	return slices.Insert(files, pos, file)
+}
+
+// intersectFiles returns the files that are contained in both sorted lists.
+func intersectFiles(a, b []string) []string {
+	var result []string
+	for _, file := range a {
+		if _, found := slices.BinarySearch(b, file); found {
+			result = append(result, file)
+		}
+	}
+	return result
+}
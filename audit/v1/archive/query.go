@@ -0,0 +1,302 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sapcc/gophercloud-sapcc/v2/audit/v1/events"
+)
+
+// maxLineSize is the maximum size of a single event in an archive file.
+const maxLineSize = 16 << 20
+
+// Get returns the archived event with the given ID, or ErrNotFound. The file
+// that contains the event is looked up in the ID index.
+func (a *Archive) Get(id string) (*events.Event, error) {
+	file, err := a.findEvent(id)
+	if err != nil {
+		return nil, err
+	}
+	if file == "" {
+		return nil, ErrNotFound
+	}
+
+	var result *events.Event
+	err = a.readFile(file, func(ev events.Event, _ []byte) bool {
+		if ev.ID == id {
+			result = &ev
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, ErrNotFound
+	}
+	return result, nil
+}
+
+// findEvent returns the file that contains the event with the given ID, or
+// an empty string if the event is not archived. Only the lookup of a pending
+// shard happens under the mutex; shards on disk are read without it.
+func (a *Archive) findEvent(id string) (string, error) {
+	name := shardName(id)
+	a.mutex.Lock()
+	shard, isPending := a.pending[name]
+	file := shard[id]
+	a.mutex.Unlock()
+	if isPending {
+		return file, nil
+	}
+
+	shard, err := a.readShard(name)
+	if err != nil {
+		return "", err
+	}
+	return shard[id], nil
+}
+
+// Query returns the archived events that match the given filters, using the
+// same semantics as events.List against Hermes:
+//
+//   - ProjectID and DomainID match the project or domain of the initiator or
+//     the target.
+//   - Search matches case-insensitively anywhere in the event body.
+//   - Sort accepts the keys and directions of events.SortKey and
+//     events.SortDirection; results are sorted by time in descending order
+//     if Sort is empty.
+//   - Offset and Limit are applied after sorting; a zero Limit returns all
+//     matching events.
+//
+// When TargetID or InitiatorID is given, only the files recorded for them in
+// the index are read.
+func (a *Archive) Query(opts events.ListOpts) ([]events.Event, error) {
+	if opts.Limit < 0 || opts.Offset < 0 {
+		return nil, errors.New("limit and offset must not be negative")
+	}
+	sorts, err := parseSort(opts.Sort)
+	if err != nil {
+		return nil, err
+	}
+	from, to, err := timeBounds(opts.Time)
+	if err != nil {
+		return nil, err
+	}
+
+	a.mutex.Lock()
+	files := a.files(from, to)
+	if opts.TargetID != "" {
+		files = intersectFiles(files, a.index.Targets[opts.TargetID])
+	}
+	if opts.InitiatorID != "" {
+		files = intersectFiles(files, a.index.Initiators[opts.InitiatorID])
+	}
+	a.mutex.Unlock()
+
+	search := []byte(strings.ToLower(opts.Search))
+	seen := make(map[string]bool)
+	var result []events.Event
+	for _, file := range files {
+		err = a.readFile(file, func(ev events.Event, raw []byte) bool {
+			if !seen[ev.ID] && matches(ev, opts) && bytes.Contains(bytes.ToLower(raw), search) {
+				seen[ev.ID] = true
+				result = append(result, ev)
+			}
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	slices.SortStableFunc(result, func(lhs, rhs events.Event) int {
+		for _, s := range sorts {
+			c := cmp.Compare(sortValue(lhs, s.Key), sortValue(rhs, s.Key))
+			if s.Direction == events.SortDesc {
+				c = -c
+			}
+			if c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+
+	if opts.Offset >= len(result) {
+		return nil, nil
+	}
+	result = result[opts.Offset:]
+	if opts.Limit > 0 && opts.Limit < len(result) {
+		result = result[:opts.Limit]
+	}
+	return result, nil
+}
+
+// readFile calls the callback for every event in the given archive file
+// until the callback returns false.
+func (a *Archive) readFile(file string, callback func(ev events.Event, raw []byte) bool) error {
+	f, err := os.Open(filepath.Join(a.dir, filepath.FromSlash(file)))
+	if errors.Is(err, os.ErrNotExist) {
+		// the index may refer to a file that was never written if writing failed
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("cannot read %s: %w", file, err)
+	}
+	defer gz.Close()
+
+	scanner := bufio.NewScanner(gz)
+	scanner.Buffer(make([]byte, 0, 64<<10), maxLineSize)
+	for scanner.Scan() {
+		var ev events.Event
+		err = json.Unmarshal(scanner.Bytes(), &ev)
+		if err != nil {
+			return fmt.Errorf("cannot parse event in %s: %w", file, err)
+		}
+		if !callback(ev, scanner.Bytes()) {
+			return nil
+		}
+	}
+	return scanner.Err()
+}
+
+func matches(ev events.Event, opts events.ListOpts) bool {
+	checks := []struct{ filter, value string }{
+		{opts.ObserverType, ev.Observer.TypeURI},
+		{opts.TargetID, ev.Target.ID},
+		{opts.TargetType, ev.Target.TypeURI},
+		{opts.InitiatorID, ev.Initiator.ID},
+		{opts.InitiatorType, ev.Initiator.TypeURI},
+		{opts.InitiatorName, ev.Initiator.Name},
+		{opts.Action, string(ev.Action)},
+		{opts.Outcome, string(ev.Outcome)},
+		{opts.RequestPath, ev.RequestPath},
+	}
+	for _, c := range checks {
+		if c.filter != "" && c.filter != c.value {
+			return false
+		}
+	}
+	if opts.ProjectID != "" && opts.ProjectID != ev.Initiator.ProjectID && opts.ProjectID != ev.Target.ProjectID {
+		return false
+	}
+	if opts.DomainID != "" && opts.DomainID != ev.Initiator.DomainID && opts.DomainID != ev.Target.DomainID {
+		return false
+	}
+
+	if len(opts.Time) == 0 {
+		return true
+	}
+	t, err := eventTime(ev)
+	if err != nil {
+		return false
+	}
+	for _, dq := range opts.Time {
+		var ok bool
+		switch dq.Filter {
+		case "":
+			ok = t.Equal(dq.Date)
+		case events.DateFilterGT:
+			ok = t.After(dq.Date)
+		case events.DateFilterGTE:
+			ok = !t.Before(dq.Date)
+		case events.DateFilterLT:
+			ok = t.Before(dq.Date)
+		case events.DateFilterLTE:
+			ok = !t.After(dq.Date)
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// timeBounds returns the earliest and latest time that the given time
+// filters allow. Zero times mean that the respective side is open.
+func timeBounds(dates []events.DateQuery) (from, to time.Time, err error) {
+	for _, dq := range dates {
+		if !dq.Filter.IsValid() {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid time filter %q", dq.Filter)
+		}
+		if dq.Filter != events.DateFilterLT && dq.Filter != events.DateFilterLTE {
+			if from.IsZero() || dq.Date.After(from) {
+				from = dq.Date
+			}
+		}
+		if dq.Filter != events.DateFilterGT && dq.Filter != events.DateFilterGTE {
+			if to.IsZero() || dq.Date.Before(to) {
+				to = dq.Date
+			}
+		}
+	}
+	return from, to, nil
+}
+
+func parseSort(sort string) ([]events.Sort, error) {
+	if sort == "" {
+		return []events.Sort{{Key: events.SortKeyTime, Direction: events.SortDesc}}, nil
+	}
+	var result []events.Sort
+	for field := range strings.SplitSeq(sort, ",") {
+		key, direction, _ := strings.Cut(field, ":")
+		s := events.Sort{Key: events.SortKey(key), Direction: events.SortDirection(direction)}
+		if !s.Key.IsValid() {
+			return nil, fmt.Errorf("events cannot be sorted by %q", s.Key)
+		}
+		if !s.Direction.IsValid() {
+			return nil, fmt.Errorf("invalid sort direction %q for %q", s.Direction, s.Key)
+		}
+		result = append(result, s)
+	}
+	return result, nil
+}
+
+func sortValue(ev events.Event, key events.SortKey) string {
+	switch key {
+	case events.SortKeyTime:
+		t, err := eventTime(ev)
+		if err != nil {
+			return ev.EventTime
+		}
+		// a fixed-width UTC representation sorts chronologically
+		return t.UTC().Format("2006-01-02T15:04:05.000000000")
+	case events.SortKeyObserverType:
+		return ev.Observer.TypeURI
+	case events.SortKeyTargetType:
+		return ev.Target.TypeURI
+	case events.SortKeyTargetID:
+		return ev.Target.ID
+	case events.SortKeyInitiatorID:
+		return ev.Initiator.ID
+	case events.SortKeyAction:
+		return string(ev.Action)
+	case events.SortKeyOutcome:
+		return string(ev.Outcome)
+	case events.SortKeyRequestPath:
+		return ev.RequestPath
+	default:
+		return ""
+	}
+}
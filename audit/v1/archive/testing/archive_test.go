@@ -0,0 +1,200 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package testing
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+	"github.com/gophercloud/gophercloud/v2/testhelper/client"
+
+	"github.com/sapcc/gophercloud-sapcc/v2/audit/v1/archive"
+	"github.com/sapcc/gophercloud-sapcc/v2/audit/v1/events"
+)
+
+func eventIDs(evs []events.Event) []string {
+	ids := make([]string, 0, len(evs))
+	for _, ev := range evs {
+		ids = append(ids, ev.ID)
+	}
+	return ids
+}
+
+func TestSyncAndQuery(t *testing.T) {
+	fakeServer := th.SetupHTTP()
+	defer fakeServer.Teardown()
+
+	var queries []string
+	fakeServer.Mux.HandleFunc("/events", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, http.MethodGet)
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		th.AssertEquals(t, "time:asc", r.URL.Query().Get("sort"))
+		queries = append(queries, r.URL.Query().Get("time"))
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if len(queries) == 1 {
+			fmt.Fprint(w, FirstSyncResponse)
+		} else {
+			fmt.Fprint(w, SecondSyncResponse)
+		}
+	})
+
+	dir := t.TempDir()
+	a, err := archive.Open(archive.Opts{Dir: dir})
+	th.AssertNoErr(t, err)
+
+	n, err := a.Sync(t.Context(), client.ServiceClient(fakeServer), events.ListOpts{})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 3, n)
+
+	// the second sync starts at the cursor and skips the repeated event
+	n, err = a.Sync(t.Context(), client.ServiceClient(fakeServer), events.ListOpts{})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, n)
+	th.CheckDeepEquals(t, []string{"", "gte:2026-10-19T09:00:00Z"}, queries)
+
+	// events are partitioned by day
+	_, err = os.Stat(filepath.Join(dir, "2026", "10", "18", "events-0000.jsonl.gz"))
+	th.AssertNoErr(t, err)
+	_, err = os.Stat(filepath.Join(dir, "2026", "10", "19", "events-0000.jsonl.gz"))
+	th.AssertNoErr(t, err)
+
+	// the index survives reopening the archive
+	a, err = archive.Open(archive.Opts{Dir: dir})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, true, a.Cursor().Equal(time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)))
+
+	ev, err := a.Get("e2")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "bob", ev.Initiator.ID)
+	_, err = a.Get("e99")
+	th.AssertEquals(t, true, errors.Is(err, archive.ErrNotFound))
+
+	testCases := []struct {
+		opts     events.ListOpts
+		expected []string
+	}{
+		{events.ListOpts{}, []string{"e4", "e3", "e2", "e1"}},
+		{events.ListOpts{TargetID: "port-1"}, []string{"e2", "e1"}},
+		{events.ListOpts{InitiatorID: "alice", Sort: "time:asc"}, []string{"e1", "e3"}},
+		{events.ListOpts{ProjectID: "p2", Action: "create"}, []string{"e4"}},
+		{events.ListOpts{Outcome: "failure"}, []string{"e2"}},
+		{events.ListOpts{Search: "NEEDLE"}, []string{"e3"}},
+		{events.ListOpts{Sort: "action:asc,time:desc"}, []string{"e4", "e1", "e2", "e3"}},
+		{events.ListOpts{Limit: 2, Offset: 1}, []string{"e3", "e2"}},
+		{events.ListOpts{Time: []events.DateQuery{
+			{Date: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), Filter: events.DateFilterGTE},
+			{Date: time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC), Filter: events.DateFilterLT},
+		}}, []string{"e3", "e2"}},
+	}
+	for _, tc := range testCases {
+		actual, err := a.Query(tc.opts)
+		th.AssertNoErr(t, err)
+		th.CheckDeepEquals(t, tc.expected, eventIDs(actual))
+	}
+
+	_, err = a.Query(events.ListOpts{Sort: "initiator_name"})
+	if err == nil {
+		t.Errorf("expected error for unsupported sort key")
+	}
+}
+
+func TestRotation(t *testing.T) {
+	fakeServer := th.SetupHTTP()
+	defer fakeServer.Teardown()
+
+	calls := 0
+	fakeServer.Mux.HandleFunc("/events", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if calls == 1 {
+			fmt.Fprint(w, FirstSyncResponse)
+		} else {
+			fmt.Fprint(w, SecondSyncResponse)
+		}
+	})
+
+	dir := t.TempDir()
+	a, err := archive.Open(archive.Opts{Dir: dir, MaxFileSize: 1})
+	th.AssertNoErr(t, err)
+
+	_, err = a.Sync(t.Context(), client.ServiceClient(fakeServer), events.ListOpts{})
+	th.AssertNoErr(t, err)
+	_, err = a.Sync(t.Context(), client.ServiceClient(fakeServer), events.ListOpts{})
+	th.AssertNoErr(t, err)
+
+	// the file of 2026-10-19 was full, so the second sync started a new one
+	_, err = os.Stat(filepath.Join(dir, "2026", "10", "19", "events-0001.jsonl.gz"))
+	th.AssertNoErr(t, err)
+
+	actual, err := a.Query(events.ListOpts{TargetID: "server-2"})
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []string{"e4"}, eventIDs(actual))
+
+	actual, err = a.Query(events.ListOpts{})
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []string{"e4", "e3", "e2", "e1"}, eventIDs(actual))
+}
+
+func TestSyncWritesIndexOnce(t *testing.T) {
+	fakeServer := th.SetupHTTP()
+	defer fakeServer.Teardown()
+
+	dir := t.TempDir()
+	calls := 0
+	fakeServer.Mux.HandleFunc("/events", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if calls == 1 {
+			next := fakeServer.Server.URL + "/events?page=2"
+			fmt.Fprint(w, strings.Replace(FirstSyncResponse, `"next": ""`, `"next": "`+next+`"`, 1))
+			return
+		}
+		// the index is not written after the first page
+		_, err := os.Stat(filepath.Join(dir, "index.json"))
+		th.AssertEquals(t, true, errors.Is(err, os.ErrNotExist))
+		fmt.Fprint(w, SecondSyncResponse)
+	})
+
+	a, err := archive.Open(archive.Opts{Dir: dir})
+	th.AssertNoErr(t, err)
+	n, err := a.Sync(t.Context(), client.ServiceClient(fakeServer), events.ListOpts{})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 2, calls)
+	th.AssertEquals(t, 4, n)
+
+	// event IDs are kept in the sharded ID index instead of in the index
+	buf, err := os.ReadFile(filepath.Join(dir, "index.json"))
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, false, strings.Contains(string(buf), `"e1"`))
+	shards, err := filepath.Glob(filepath.Join(dir, "ids", "*.json"))
+	th.AssertNoErr(t, err)
+	ids := make(map[string]string)
+	for _, shard := range shards {
+		buf, err = os.ReadFile(shard)
+		th.AssertNoErr(t, err)
+		th.AssertNoErr(t, json.Unmarshal(buf, &ids))
+	}
+	th.CheckDeepEquals(t, map[string]string{
+		"e1": "2026/10/18/events-0000.jsonl.gz",
+		"e2": "2026/10/19/events-0000.jsonl.gz",
+		"e3": "2026/10/19/events-0000.jsonl.gz",
+		"e4": "2026/10/19/events-0000.jsonl.gz",
+	}, ids)
+
+	ev, err := a.Get("e1")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "alice", ev.Initiator.ID)
+}
@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package testing
+
+// FirstSyncResponse contains three events on two days.
+const FirstSyncResponse = `
+{
+  "next": "",
+  "events": [
+    {
+      "id": "e1",
+      "eventTime": "2026-10-18T23:59:00.000000+00:00",
+      "action": "create",
+      "outcome": "success",
+      "initiator": {"typeURI": "service/security/account/user", "id": "alice", "project_id": "p1"},
+      "target": {"typeURI": "network/port", "id": "port-1", "project_id": "p1"},
+      "observer": {"typeURI": "service/network", "id": "neutron"}
+    },
+    {
+      "id": "e2",
+      "eventTime": "2026-10-19T08:00:00.000000+00:00",
+      "action": "delete",
+      "outcome": "failure",
+      "initiator": {"typeURI": "service/security/account/user", "id": "bob", "project_id": "p1"},
+      "target": {"typeURI": "network/port", "id": "port-1", "project_id": "p1"},
+      "observer": {"typeURI": "service/network", "id": "neutron"}
+    },
+    {
+      "id": "e3",
+      "eventTime": "2026-10-19T09:00:00.000000+00:00",
+      "action": "update",
+      "outcome": "success",
+      "initiator": {"typeURI": "service/security/account/user", "id": "alice", "project_id": "p2"},
+      "target": {"typeURI": "compute/server", "id": "server-1", "project_id": "p2", "name": "needle-server"},
+      "observer": {"typeURI": "service/compute", "id": "nova"}
+    }
+  ],
+  "total": 3
+}
+`
+
+// SecondSyncResponse repeats the newest event of FirstSyncResponse, as
+// Hermes does for a query starting at the cursor, and adds a new one.
+const SecondSyncResponse = `
+{
+  "next": "",
+  "events": [
+    {
+      "id": "e3",
+      "eventTime": "2026-10-19T09:00:00.000000+00:00",
+      "action": "update",
+      "outcome": "success",
+      "initiator": {"typeURI": "service/security/account/user", "id": "alice", "project_id": "p2"},
+      "target": {"typeURI": "compute/server", "id": "server-1", "project_id": "p2", "name": "needle-server"},
+      "observer": {"typeURI": "service/compute", "id": "nova"}
+    },
+    {
+      "id": "e4",
+      "eventTime": "2026-10-19T10:00:00.000000+00:00",
+      "action": "create",
+      "outcome": "success",
+      "initiator": {"typeURI": "service/security/account/user", "id": "bob", "project_id": "p2"},
+      "target": {"typeURI": "compute/server", "id": "server-2", "project_id": "p2"},
+      "observer": {"typeURI": "service/compute", "id": "nova"}
+    }
+  ],
+  "total": 2
+}
+`
@@ -0,0 +1,218 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package attributes
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2"
+)
+
+// maxAutoLimit is the largest limit that Attributes uses when it grows the
+// limit to retrieve all values of an attribute.
+const maxAutoLimit = 6400
+
+// AttributesOpts configures an Attributes catalogue.
+type AttributesOpts struct {
+	// The domain to load attribute values for.
+	DomainID string
+	// The project to load attribute values for.
+	ProjectID string
+	// MaxDepth limits the depth of hierarchical values like type URIs.
+	MaxDepth int
+	// Limit is the maximum number of values to load per attribute. When it
+	// is zero, the limit is raised until all values have been loaded.
+	Limit int
+	// TTL is the time after which loaded values are reloaded. When it is
+	// zero, loaded values are kept until Invalidate is called.
+	TTL time.Duration
+}
+
+// Attributes is a cache of attribute values for a domain or project. It is
+// intended for autocompletion and for validating filter values before they
+// are given to events.List. It is safe for concurrent use.
+type Attributes struct {
+	client *gophercloud.ServiceClient
+	opts   AttributesOpts
+
+	mutex   sync.Mutex
+	entries map[Name]attributeEntry
+}
+
+type attributeEntry struct {
+	values    []string
+	truncated bool
+	loadedAt  time.Time
+}
+
+// NewAttributes returns an empty Attributes catalogue. Values are loaded
+// lazily on first use.
+func NewAttributes(client *gophercloud.ServiceClient, opts AttributesOpts) *Attributes {
+	return &Attributes{
+		client:  client,
+		opts:    opts,
+		entries: make(map[Name]attributeEntry),
+	}
+}
+
+// Load loads the values of the given attributes, or of all supported
+// attributes if none are given. Values that are already cached are kept.
+func (a *Attributes) Load(ctx context.Context, names ...Name) error {
+	if len(names) == 0 {
+		names = Names()
+	}
+	for _, name := range names {
+		_, err := a.entry(ctx, name)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Values returns the sorted values of the given attribute.
+func (a *Attributes) Values(ctx context.Context, name Name) ([]string, error) {
+	e, err := a.entry(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return slices.Clone(e.values), nil
+}
+
+// IsTruncated reports whether the loaded values of the given attribute
+// may be incomplete because Hermes returned as many values as the limit
+// allowed.
+func (a *Attributes) IsTruncated(ctx context.Context, name Name) (bool, error) {
+	e, err := a.entry(ctx, name)
+	if err != nil {
+		return false, err
+	}
+	return e.truncated, nil
+}
+
+// Suggest returns all values of the given attribute that start with the
+// given prefix.
+func (a *Attributes) Suggest(ctx context.Context, name Name, prefix string) ([]string, error) {
+	e, err := a.entry(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	var result []string
+	for _, v := range e.values {
+		if strings.HasPrefix(v, prefix) {
+			result = append(result, v)
+		}
+	}
+	return result, nil
+}
+
+// Validate returns an UnknownValueError if the given value is not a known
+// value of the given attribute. If the loaded values are truncated, every
+// value is accepted since it may be among the values that were cut off.
+func (a *Attributes) Validate(ctx context.Context, name Name, value string) error {
+	e, err := a.entry(ctx, name)
+	if err != nil {
+		return err
+	}
+	if e.truncated {
+		return nil
+	}
+	if _, found := slices.BinarySearch(e.values, value); !found {
+		return UnknownValueError{Name: name, Value: value}
+	}
+	return nil
+}
+
+// Invalidate drops the cached values of the given attributes, or of all
+// attributes if none are given.
+func (a *Attributes) Invalidate(names ...Name) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if len(names) == 0 {
+		clear(a.entries)
+		return
+	}
+	for _, name := range names {
+		delete(a.entries, name)
+	}
+}
+
+func (a *Attributes) entry(ctx context.Context, name Name) (attributeEntry, error) {
+	if !name.IsValid() {
+		return attributeEntry{}, fmt.Errorf("unsupported attribute name %q", name)
+	}
+
+	a.mutex.Lock()
+	e, exists := a.entries[name]
+	a.mutex.Unlock()
+	if exists && (a.opts.TTL == 0 || time.Since(e.loadedAt) < a.opts.TTL) {
+		return e, nil
+	}
+
+	e, err := a.load(ctx, name)
+	if err != nil {
+		return attributeEntry{}, err
+	}
+
+	a.mutex.Lock()
+	a.entries[name] = e
+	a.mutex.Unlock()
+	return e, nil
+}
+
+func (a *Attributes) load(ctx context.Context, name Name) (attributeEntry, error) {
+	opts := ListOpts{
+		MaxDepth:  a.opts.MaxDepth,
+		Limit:     a.opts.Limit,
+		DomainID:  a.opts.DomainID,
+		ProjectID: a.opts.ProjectID,
+	}
+	if opts.Limit == 0 {
+		opts.Limit = DefaultLimit
+	}
+
+	for {
+		page, err := List(a.client, string(name), opts).AllPages(ctx)
+		if err != nil {
+			return attributeEntry{}, err
+		}
+		values, err := ExtractAttributes(page)
+		if err != nil {
+			return attributeEntry{}, err
+		}
+		truncated, err := page.(AttributePage).IsTruncated()
+		if err != nil {
+			return attributeEntry{}, err
+		}
+
+		if truncated && a.opts.Limit == 0 && opts.Limit < maxAutoLimit {
+			opts.Limit = min(2*opts.Limit, maxAutoLimit)
+			continue
+		}
+
+		slices.Sort(values)
+		return attributeEntry{
+			values:    slices.Compact(values),
+			truncated: truncated,
+			loadedAt:  time.Now(),
+		}, nil
+	}
+}
+
+// UnknownValueError is returned by Attributes.Validate when a value is not
+// known to Hermes.
+type UnknownValueError struct {
+	Name  Name
+	Value string
+}
+
+// Error implements the builtin/error interface.
+func (e UnknownValueError) Error() string {
+	return fmt.Sprintf("unknown value %q for attribute %q", e.Value, e.Name)
+}
@@ -4,10 +4,55 @@
 package attributes
 
 import (
+	"slices"
+
 	"github.com/gophercloud/gophercloud/v2"
 	"github.com/gophercloud/gophercloud/v2/pagination"
 )
 
+// DefaultLimit is the number of values that Hermes returns when no Limit is
+// given in ListOpts.
+const DefaultLimit = 50
+
+// Name is the name of an event attribute whose values can be listed.
+type Name string
+
+// These are the attribute names supported by Hermes. They correspond to the
+// filters of the same name in events.ListOpts.
+const (
+	NameObserverID    Name = "observer_id"
+	NameObserverType  Name = "observer_type"
+	NameTargetID      Name = "target_id"
+	NameTargetType    Name = "target_type"
+	NameInitiatorID   Name = "initiator_id"
+	NameInitiatorType Name = "initiator_type"
+	NameInitiatorName Name = "initiator_name"
+	NameAction        Name = "action"
+	NameOutcome       Name = "outcome"
+	NameRequestPath   Name = "request_path"
+)
+
+// Names returns all attribute names supported by Hermes.
+func Names() []Name {
+	return []Name{
+		NameObserverID,
+		NameObserverType,
+		NameTargetID,
+		NameTargetType,
+		NameInitiatorID,
+		NameInitiatorType,
+		NameInitiatorName,
+		NameAction,
+		NameOutcome,
+		NameRequestPath,
+	}
+}
+
+// IsValid returns whether the attribute name is supported by Hermes.
+func (n Name) IsValid() bool {
+	return slices.Contains(Names(), n)
+}
+
 // ListOptsBuilder allows extensions to add additional parameters to the
 // List request.
 type ListOptsBuilder interface {
@@ -18,7 +63,10 @@ type ListOptsBuilder interface {
 // Filtering is achieved by passing in filter value. Page and PerPage are used
 // for pagination.
 type ListOpts struct {
-	MaxDepth  int    `q:"max_depth"`
+	// MaxDepth limits the depth of hierarchical values like type URIs.
+	MaxDepth int `q:"max_depth"`
+	// Limit is the maximum number of values to return. Hermes uses
+	// DefaultLimit when no limit is given.
 	Limit     int    `q:"limit"`
 	DomainID  string `q:"domain_id"`
 	ProjectID string `q:"project_id"`
@@ -30,7 +78,8 @@ func (opts ListOpts) ToAttributeListQuery() (string, error) {
 	return q.String(), err
 }
 
-// List retrieves a list of Attributes.
+// List retrieves a list of Attributes. The name should be one of the Name
+// constants.
 func List(client *gophercloud.ServiceClient, name string, opts ListOptsBuilder) pagination.Pager {
 	url := listURL(client, name)
 	if opts != nil {
@@ -4,6 +4,8 @@
 package attributes
 
 import (
+	"strconv"
+
 	"github.com/gophercloud/gophercloud/v2/pagination"
 )
 
@@ -18,11 +20,29 @@ func (r AttributePage) IsEmpty() (bool, error) {
 	return len(attributes) == 0, err
 }
 
-// NextPageURL extracts the "next" link from the links section of the result.
+// NextPageURL always returns an empty string: Hermes returns all attribute
+// values in a single response of at most Limit values and does not support
+// an offset. Use IsTruncated to find out whether values were cut off.
 func (r AttributePage) NextPageURL() (string, error) {
 	return "", nil
 }
 
+// IsTruncated reports whether the page holds as many values as the limit
+// of the request allowed, in which case Hermes may have more values than
+// were returned.
+func (r AttributePage) IsTruncated() (bool, error) {
+	limit := DefaultLimit
+	if l := r.URL.Query().Get("limit"); l != "" {
+		var err error
+		limit, err = strconv.Atoi(l)
+		if err != nil {
+			return false, err
+		}
+	}
+	attributes, err := ExtractAttributes(r)
+	return limit > 0 && len(attributes) >= limit, err
+}
+
 // ExtractAttributes accepts a Page struct, specifically an AttributePage struct,
 // and extracts the elements into a slice of Attribute structs. In other words,
 // a generic collection is mapped into a relevant slice.
@@ -0,0 +1,19 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package testing
+
+const ListResponse = `
+[
+  "service/network",
+  "service/compute",
+  "service/security"
+]
+`
+
+const TruncatedListResponse = `
+[
+  "create",
+  "delete"
+]
+`
@@ -0,0 +1,123 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package testing
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+	"github.com/gophercloud/gophercloud/v2/testhelper/client"
+
+	"github.com/sapcc/gophercloud-sapcc/v2/audit/v1/attributes"
+)
+
+func TestList(t *testing.T) {
+	fakeServer := th.SetupHTTP()
+	defer fakeServer.Teardown()
+
+	fakeServer.Mux.HandleFunc("/attributes/observer_type", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, http.MethodGet)
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		th.TestFormValues(t, r, map[string]string{"limit": "3", "project_id": "p1"})
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprint(w, ListResponse)
+	})
+
+	opts := attributes.ListOpts{Limit: 3, ProjectID: "p1"}
+	page, err := attributes.List(client.ServiceClient(fakeServer), string(attributes.NameObserverType), opts).AllPages(t.Context())
+	th.AssertNoErr(t, err)
+
+	actual, err := attributes.ExtractAttributes(page)
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []string{"service/network", "service/compute", "service/security"}, actual)
+
+	truncated, err := page.(attributes.AttributePage).IsTruncated()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, true, truncated)
+}
+
+func TestAttributes(t *testing.T) {
+	fakeServer := th.SetupHTTP()
+	defer fakeServer.Teardown()
+
+	var requests []string
+	fakeServer.Mux.HandleFunc("/attributes/action", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, http.MethodGet)
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		th.AssertEquals(t, "d1", r.URL.Query().Get("domain_id"))
+		requests = append(requests, r.URL.Query().Get("limit"))
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		// with the default limit, the response is full and "update" is cut off
+		values := []string{"delete", "create"}
+		for i := range 48 {
+			values = append(values, fmt.Sprintf("noise/%02d", i))
+		}
+		if r.URL.Query().Get("limit") != "50" {
+			values = append(values, "update")
+		}
+		th.AssertNoErr(t, json.NewEncoder(w).Encode(values))
+	})
+
+	catalogue := attributes.NewAttributes(client.ServiceClient(fakeServer), attributes.AttributesOpts{DomainID: "d1"})
+
+	values, err := catalogue.Suggest(t.Context(), attributes.NameAction, "de")
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []string{"delete"}, values)
+	// the first response was full, so the limit must have been raised
+	th.CheckDeepEquals(t, []string{"50", "100"}, requests)
+
+	truncated, err := catalogue.IsTruncated(t.Context(), attributes.NameAction)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, false, truncated)
+
+	th.AssertNoErr(t, catalogue.Validate(t.Context(), attributes.NameAction, "update"))
+	err = catalogue.Validate(t.Context(), attributes.NameAction, "explode")
+	var uve attributes.UnknownValueError
+	th.AssertEquals(t, true, errors.As(err, &uve))
+	th.AssertEquals(t, "explode", uve.Value)
+
+	// values are served from the cache until invalidated
+	th.AssertEquals(t, 2, len(requests))
+	catalogue.Invalidate(attributes.NameAction)
+	th.AssertNoErr(t, catalogue.Load(t.Context(), attributes.NameAction))
+	th.AssertEquals(t, 4, len(requests))
+
+	_, err = catalogue.Values(t.Context(), "flavor")
+	if err == nil {
+		t.Errorf("expected error for unsupported attribute name")
+	}
+}
+
+func TestAttributesLimit(t *testing.T) {
+	fakeServer := th.SetupHTTP()
+	defer fakeServer.Teardown()
+
+	fakeServer.Mux.HandleFunc("/attributes/action", func(w http.ResponseWriter, r *http.Request) {
+		th.TestFormValues(t, r, map[string]string{"limit": "2"})
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprint(w, TruncatedListResponse)
+	})
+
+	catalogue := attributes.NewAttributes(client.ServiceClient(fakeServer), attributes.AttributesOpts{Limit: 2})
+
+	truncated, err := catalogue.IsTruncated(t.Context(), attributes.NameAction)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, true, truncated)
+
+	// values cannot be rejected when the list may be incomplete
+	th.AssertNoErr(t, catalogue.Validate(t.Context(), attributes.NameAction, "update"))
+}
@@ -0,0 +1,175 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package dataplaneconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/gophercloud/gophercloud/v2"
+
+	"github.com/sapcc/gophercloud-sapcc/v2/internal/parallel"
+)
+
+// DefaultReconcileConcurrency is the number of projects that Reconcile
+// processes in parallel when ReconcileOpts.Concurrency is not set.
+const DefaultReconcileConcurrency = 4
+
+// ReconcileOpts configures a Reconcile run.
+type ReconcileOpts struct {
+	// Desired maps project IDs to their desired dataplane configuration.
+	Desired map[string]PutOpts
+	// Prune lists project IDs whose configuration shall be deleted if they
+	// do not appear in Desired. Projects that are neither in Desired nor in
+	// Prune are not touched.
+	Prune []string
+	// Concurrency limits the number of projects processed in parallel.
+	Concurrency int
+	// DryRun computes the diff against the live state without applying it.
+	DryRun bool
+}
+
+// ReconcileReport describes the outcome of a Reconcile run. All project ID
+// lists are sorted. In a dry run, the lists describe the changes that would
+// have been applied.
+type ReconcileReport struct {
+	Created   []string
+	Updated   []string
+	Deleted   []string
+	Unchanged []string
+	// Failed maps project IDs to the error that occurred while reading,
+	// validating or applying their configuration.
+	Failed map[string]error
+}
+
+// Err returns the errors in Failed joined into one, or nil if no project
+// failed.
+func (r ReconcileReport) Err() error {
+	ids := make([]string, 0, len(r.Failed))
+	for id := range r.Failed {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	errs := make([]error, 0, len(ids))
+	for _, id := range ids {
+		errs = append(errs, fmt.Errorf("project %s: %w", id, r.Failed[id]))
+	}
+	return errors.Join(errs...)
+}
+
+type reconcileAction int
+
+const (
+	actionUnchanged reconcileAction = iota
+	actionCreate
+	actionUpdate
+	actionDelete
+)
+
+// reconcileOutcome is the result of reconcileProject.
+type reconcileOutcome struct {
+	action reconcileAction
+	err    error
+}
+
+// Reconcile brings the dataplane configuration of many projects in line
+// with opts.Desired. The live configuration of every project is read with
+// Get and compared to the desired one. Changes are written with
+// CompareAndPut, so that concurrent modifications by other clients are
+// reported as a ConflictError instead of being overwritten.
+//
+// Errors for individual projects are collected in the report; they do not
+// abort the run. The returned error is only non-nil if the context was
+// cancelled.
+func Reconcile(ctx context.Context, c *gophercloud.ServiceClient, opts ReconcileOpts) (ReconcileReport, error) {
+	projectIDs := make([]string, 0, len(opts.Desired)+len(opts.Prune))
+	for id := range opts.Desired {
+		projectIDs = append(projectIDs, id)
+	}
+	for _, id := range opts.Prune {
+		if _, exists := opts.Desired[id]; !exists {
+			projectIDs = append(projectIDs, id)
+		}
+	}
+	slices.Sort(projectIDs)
+	projectIDs = slices.Compact(projectIDs)
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultReconcileConcurrency
+	}
+
+	report := ReconcileReport{Failed: make(map[string]error)}
+	process := func(projectID string) reconcileOutcome {
+		desired, isDesired := opts.Desired[projectID]
+		action, err := reconcileProject(ctx, c, projectID, desired, isDesired, opts.DryRun)
+		return reconcileOutcome{action, err}
+	}
+	err := parallel.ForEach(ctx, projectIDs, concurrency, process, func(projectID string, outcome reconcileOutcome) {
+		if outcome.err != nil {
+			report.Failed[projectID] = outcome.err
+			return
+		}
+		switch outcome.action {
+		case actionCreate:
+			report.Created = append(report.Created, projectID)
+		case actionUpdate:
+			report.Updated = append(report.Updated, projectID)
+		case actionDelete:
+			report.Deleted = append(report.Deleted, projectID)
+		case actionUnchanged:
+			report.Unchanged = append(report.Unchanged, projectID)
+		}
+	})
+
+	for _, list := range [][]string{report.Created, report.Updated, report.Deleted, report.Unchanged} {
+		slices.Sort(list)
+	}
+	return report, err
+}
+
+func reconcileProject(ctx context.Context, c *gophercloud.ServiceClient, projectID string, desired PutOpts, isDesired, dryRun bool) (reconcileAction, error) {
+	if isDesired {
+		err := desired.Validate()
+		if err != nil {
+			return actionUnchanged, err
+		}
+	}
+
+	live, err := Get(ctx, c, projectID).Extract()
+	if err != nil {
+		return actionUnchanged, err
+	}
+	// Get returns a zero-valued config for projects without configuration
+	exists := !live.UpdatedAt.IsZero()
+
+	var action reconcileAction
+	switch {
+	case !isDesired && exists:
+		action = actionDelete
+	case !isDesired:
+		action = actionUnchanged
+	case live.Enabled == desired.Enabled && live.TargetBucket == desired.TargetBucket:
+		action = actionUnchanged
+	case exists:
+		action = actionUpdate
+	default:
+		action = actionCreate
+	}
+	if dryRun {
+		return action, nil
+	}
+
+	switch action {
+	case actionCreate, actionUpdate:
+		err = CompareAndPut(ctx, c, projectID, live.UpdatedAt, desired).Err
+	case actionDelete:
+		err = Delete(ctx, c, projectID).ExtractErr()
+	case actionUnchanged:
+	}
+	return action, err
+}
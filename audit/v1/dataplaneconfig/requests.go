@@ -5,17 +5,64 @@ package dataplaneconfig
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/gophercloud/gophercloud/v2"
 )
 
 // PutOpts specifies the fields for a Put request.
 type PutOpts struct {
-	Enabled      bool   `json:"enabled"`
+	Enabled bool `json:"enabled"`
+	// TargetBucket is required when Enabled is true. It must be a valid
+	// bucket name, see Validate.
 	TargetBucket string `json:"target_bucket,omitempty"`
 }
 
+// Validate checks the PutOpts on the client side. TargetBucket must be set
+// when Enabled is true, and must follow the S3 bucket naming rules: 3 to 63
+// characters, only lowercase letters, digits, dots and hyphens, beginning and
+// ending with a letter or digit, no adjacent dots and not formatted like an
+// IP address.
+func (opts PutOpts) Validate() error {
+	if opts.TargetBucket == "" {
+		if opts.Enabled {
+			return errors.New("option TargetBucket is required, when Enabled is set")
+		}
+		return nil
+	}
+	return validateBucketName(opts.TargetBucket)
+}
+
+func validateBucketName(name string) error {
+	if len(name) < 3 || len(name) > 63 {
+		return fmt.Errorf("invalid TargetBucket %q: must be between 3 and 63 characters long", name)
+	}
+	for _, c := range name {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '.' && c != '-' {
+			return fmt.Errorf("invalid TargetBucket %q: may only contain lowercase letters, digits, dots and hyphens", name)
+		}
+	}
+	if !isAlphanumeric(name[0]) || !isAlphanumeric(name[len(name)-1]) {
+		return fmt.Errorf("invalid TargetBucket %q: must begin and end with a letter or digit", name)
+	}
+	if strings.Contains(name, "..") {
+		return fmt.Errorf("invalid TargetBucket %q: must not contain adjacent dots", name)
+	}
+	if net.ParseIP(name) != nil {
+		return fmt.Errorf("invalid TargetBucket %q: must not be formatted as an IP address", name)
+	}
+	return nil
+}
+
+func isAlphanumeric(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9')
+}
+
 // Get retrieves the dataplane configuration for the given project.
 // Returns the default (disabled) config when none has been set.
 func Get(ctx context.Context, c *gophercloud.ServiceClient, projectID string) (r GetResult) {
@@ -28,6 +75,8 @@ func Get(ctx context.Context, c *gophercloud.ServiceClient, projectID string) (r
 }
 
 // Put creates or replaces the dataplane configuration for the given project.
+// The opts are sent as they are; call PutOpts.Validate beforehand to check
+// them on the client side.
 func Put(ctx context.Context, c *gophercloud.ServiceClient, projectID string, opts PutOpts) (r PutResult) {
 	//nolint:bodyclose // already handled by gophercloud
 	resp, err := c.Put(ctx, resourceURL(c, projectID), opts, &r.Body, &gophercloud.RequestOpts{
@@ -37,6 +86,70 @@ func Put(ctx context.Context, c *gophercloud.ServiceClient, projectID string, op
 	return
 }
 
+// CompareAndPut replaces the dataplane configuration for the given project,
+// but only if it has not been modified since it was read. The updatedAt
+// argument must be the UpdatedAt value of the configuration returned by Get
+// (a zero value for a project without configuration).
+//
+// The write carries an If-Unmodified-Since precondition, or an
+// If-None-Match precondition for a project without configuration, so the
+// configuration is not read again. If the server reports a concurrent
+// modification, the result contains a ConflictError.
+func CompareAndPut(ctx context.Context, c *gophercloud.ServiceClient, projectID string, updatedAt time.Time, opts PutOpts) (r PutResult) {
+	err := opts.Validate()
+	if err != nil {
+		r.Err = err
+		return
+	}
+
+	reqOpts := &gophercloud.RequestOpts{
+		OkCodes: []int{http.StatusOK},
+	}
+	if updatedAt.IsZero() {
+		reqOpts.MoreHeaders = map[string]string{
+			"If-None-Match": "*",
+		}
+	} else {
+		// HTTP dates have a precision of one second, so round up to not reject
+		// our own write when updatedAt has a fractional part
+		since := updatedAt.Truncate(time.Second)
+		if since.Before(updatedAt) {
+			since = since.Add(time.Second)
+		}
+		reqOpts.MoreHeaders = map[string]string{
+			"If-Unmodified-Since": since.UTC().Format(http.TimeFormat),
+		}
+	}
+
+	//nolint:bodyclose // already handled by gophercloud
+	resp, err := c.Put(ctx, resourceURL(c, projectID), opts, &r.Body, reqOpts)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	if gophercloud.ResponseCodeIs(r.Err, http.StatusPreconditionFailed) {
+		r.Err = ConflictError{
+			ProjectID: projectID,
+			Expected:  updatedAt,
+		}
+	}
+	return
+}
+
+// ConflictError is returned by CompareAndPut when the dataplane
+// configuration was modified concurrently.
+type ConflictError struct {
+	ProjectID string
+	// The UpdatedAt value that was given to CompareAndPut.
+	Expected time.Time
+}
+
+// Error implements the builtin/error interface.
+func (e ConflictError) Error() string {
+	if e.Expected.IsZero() {
+		return fmt.Sprintf("dataplane config of project %s was created concurrently", e.ProjectID)
+	}
+	return fmt.Sprintf("dataplane config of project %s was modified after %s",
+		e.ProjectID, e.Expected.Format(time.RFC3339))
+}
+
 // Delete removes the dataplane configuration for the given project.
 // Deleting a non-existent config is a no-op (returns 204).
 func Delete(ctx context.Context, c *gophercloud.ServiceClient, projectID string) (r DeleteResult) {
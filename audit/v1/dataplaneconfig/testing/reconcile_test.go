@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package testing
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+	"github.com/gophercloud/gophercloud/v2/testhelper/client"
+
+	"github.com/sapcc/gophercloud-sapcc/v2/audit/v1/dataplaneconfig"
+)
+
+// fakeDataplaneAPI is a minimal in-memory implementation of the dataplane
+// config endpoint.
+type fakeDataplaneAPI struct {
+	mutex   sync.Mutex
+	configs map[string]dataplaneconfig.DataplaneConfig
+	reads   int
+	writes  int
+}
+
+func (f *fakeDataplaneAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	projectID := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/projects/"), "/dataplane-config")
+	w.Header().Add("Content-Type", "application/json")
+
+	switch r.Method {
+	case http.MethodGet:
+		f.reads++
+		cfg, exists := f.configs[projectID]
+		if !exists {
+			cfg = dataplaneconfig.DataplaneConfig{ProjectID: projectID}
+		}
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(cfg) //nolint:errcheck
+	case http.MethodPut:
+		f.writes++
+		var opts dataplaneconfig.PutOpts
+		json.NewDecoder(r.Body).Decode(&opts) //nolint:errcheck
+		cfg := dataplaneconfig.DataplaneConfig{
+			ProjectID:    projectID,
+			Enabled:      opts.Enabled,
+			TargetBucket: opts.TargetBucket,
+			UpdatedAt:    expectedConfig.UpdatedAt,
+			UpdatedBy:    "reconciler",
+		}
+		f.configs[projectID] = cfg
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(cfg) //nolint:errcheck
+	case http.MethodDelete:
+		f.writes++
+		delete(f.configs, projectID)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func newFakeDataplaneAPI() *fakeDataplaneAPI {
+	return &fakeDataplaneAPI{configs: map[string]dataplaneconfig.DataplaneConfig{
+		"p-update": {ProjectID: "p-update", Enabled: true, TargetBucket: "old-bucket", UpdatedAt: expectedConfig.UpdatedAt},
+		"p-same":   {ProjectID: "p-same", Enabled: true, TargetBucket: "audit-bucket", UpdatedAt: expectedConfig.UpdatedAt},
+		"p-delete": {ProjectID: "p-delete", Enabled: true, TargetBucket: "audit-bucket", UpdatedAt: expectedConfig.UpdatedAt},
+	}}
+}
+
+var reconcileOpts = dataplaneconfig.ReconcileOpts{
+	Desired: map[string]dataplaneconfig.PutOpts{
+		"p-create":  {Enabled: true, TargetBucket: "audit-bucket"},
+		"p-update":  {Enabled: true, TargetBucket: "audit-bucket"},
+		"p-same":    {Enabled: true, TargetBucket: "audit-bucket"},
+		"p-invalid": {Enabled: true},
+	},
+	Prune:       []string{"p-delete", "p-absent"},
+	Concurrency: 2,
+}
+
+func TestReconcile(t *testing.T) {
+	fakeServer := th.SetupHTTP()
+	defer fakeServer.Teardown()
+
+	api := newFakeDataplaneAPI()
+	fakeServer.Mux.Handle("/projects/", api)
+
+	report, err := dataplaneconfig.Reconcile(t.Context(), client.ServiceClient(fakeServer), reconcileOpts)
+	th.AssertNoErr(t, err)
+
+	th.CheckDeepEquals(t, []string{"p-create"}, report.Created)
+	th.CheckDeepEquals(t, []string{"p-update"}, report.Updated)
+	th.CheckDeepEquals(t, []string{"p-delete"}, report.Deleted)
+	th.CheckDeepEquals(t, []string{"p-absent", "p-same"}, report.Unchanged)
+	th.AssertEquals(t, 1, len(report.Failed))
+	if report.Failed["p-invalid"] == nil {
+		t.Errorf("expected p-invalid to fail validation")
+	}
+	if report.Err() == nil {
+		t.Errorf("expected report to have an error")
+	}
+
+	// every valid project is read once; writes are guarded by preconditions
+	th.AssertEquals(t, 5, api.reads)
+	th.AssertEquals(t, 3, api.writes)
+	th.AssertEquals(t, "audit-bucket", api.configs["p-update"].TargetBucket)
+	_, exists := api.configs["p-delete"]
+	th.AssertEquals(t, false, exists)
+}
+
+func TestReconcileDryRun(t *testing.T) {
+	fakeServer := th.SetupHTTP()
+	defer fakeServer.Teardown()
+
+	api := newFakeDataplaneAPI()
+	fakeServer.Mux.Handle("/projects/", api)
+
+	opts := reconcileOpts
+	opts.DryRun = true
+	report, err := dataplaneconfig.Reconcile(t.Context(), client.ServiceClient(fakeServer), opts)
+	th.AssertNoErr(t, err)
+
+	th.CheckDeepEquals(t, []string{"p-create"}, report.Created)
+	th.CheckDeepEquals(t, []string{"p-update"}, report.Updated)
+	th.CheckDeepEquals(t, []string{"p-delete"}, report.Deleted)
+	th.AssertEquals(t, 0, api.writes)
+}
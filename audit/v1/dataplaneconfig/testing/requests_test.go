@@ -4,6 +4,7 @@
 package testing
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"testing"
@@ -109,3 +110,96 @@ func TestDelete(t *testing.T) {
 	res := dataplaneconfig.Delete(t.Context(), client.ServiceClient(fakeServer), testProjectID)
 	th.AssertNoErr(t, res.Err)
 }
+
+func TestPutValidation(t *testing.T) {
+	invalid := map[string]dataplaneconfig.PutOpts{
+		"enabled without bucket": {Enabled: true},
+		"too short":              {TargetBucket: "ab"},
+		"uppercase":              {TargetBucket: "Audit-Bucket"},
+		"underscore":             {TargetBucket: "audit_bucket"},
+		"leading hyphen":         {TargetBucket: "-audit"},
+		"trailing dot":           {TargetBucket: "audit."},
+		"adjacent dots":          {TargetBucket: "audit..bucket"},
+		"ip address":             {TargetBucket: "192.168.5.4"},
+	}
+	for name, opts := range invalid {
+		if opts.Validate() == nil {
+			t.Errorf("%s: expected validation error, got none", name)
+		}
+	}
+
+	th.AssertNoErr(t, dataplaneconfig.PutOpts{}.Validate())
+	th.AssertNoErr(t, dataplaneconfig.PutOpts{Enabled: true, TargetBucket: "audit.bucket-01"}.Validate())
+
+	// CompareAndPut must not send invalid opts to the server
+	fakeServer := th.SetupHTTP()
+	defer fakeServer.Teardown()
+
+	_, err := dataplaneconfig.CompareAndPut(t.Context(), client.ServiceClient(fakeServer), testProjectID, time.Time{}, dataplaneconfig.PutOpts{Enabled: true}).Extract()
+	if err == nil {
+		t.Errorf("expected validation error from CompareAndPut, got none")
+	}
+}
+
+func TestCompareAndPut(t *testing.T) {
+	fakeServer := th.SetupHTTP()
+	defer fakeServer.Teardown()
+
+	fakeServer.Mux.HandleFunc("/projects/"+testProjectID+"/dataplane-config", func(w http.ResponseWriter, r *http.Request) {
+		// the configuration is not read again before writing
+		th.TestMethod(t, r, http.MethodPut)
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		th.TestHeader(t, r, "If-Unmodified-Since", "Wed, 08 Jul 2026 10:00:00 GMT")
+		th.TestJSONRequest(t, r, PutRequest)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, PutResponse)
+	})
+
+	opts := dataplaneconfig.PutOpts{
+		Enabled:      true,
+		TargetBucket: "audit-bucket",
+	}
+
+	cfg, err := dataplaneconfig.CompareAndPut(t.Context(), client.ServiceClient(fakeServer), testProjectID, expectedConfig.UpdatedAt, opts).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertDeepEquals(t, expectedConfig, *cfg)
+}
+
+func TestCompareAndPutCreateConflict(t *testing.T) {
+	fakeServer := th.SetupHTTP()
+	defer fakeServer.Teardown()
+
+	fakeServer.Mux.HandleFunc("/projects/"+testProjectID+"/dataplane-config", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, http.MethodPut)
+		th.TestHeader(t, r, "If-None-Match", "*")
+
+		// another admin created the configuration in between our read and our write
+		w.WriteHeader(http.StatusPreconditionFailed)
+	})
+
+	_, err := dataplaneconfig.CompareAndPut(t.Context(), client.ServiceClient(fakeServer), testProjectID, time.Time{}, dataplaneconfig.PutOpts{}).Extract()
+
+	var conflict dataplaneconfig.ConflictError
+	th.AssertEquals(t, true, errors.As(err, &conflict))
+	th.AssertEquals(t, true, conflict.Expected.IsZero())
+}
+
+func TestCompareAndPutPreconditionFailed(t *testing.T) {
+	fakeServer := th.SetupHTTP()
+	defer fakeServer.Teardown()
+
+	fakeServer.Mux.HandleFunc("/projects/"+testProjectID+"/dataplane-config", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, http.MethodPut)
+
+		// another admin wrote in between our read and our write
+		w.WriteHeader(http.StatusPreconditionFailed)
+	})
+
+	_, err := dataplaneconfig.CompareAndPut(t.Context(), client.ServiceClient(fakeServer), testProjectID, expectedConfig.UpdatedAt, dataplaneconfig.PutOpts{}).Extract()
+
+	var conflict dataplaneconfig.ConflictError
+	th.AssertEquals(t, true, errors.As(err, &conflict))
+	th.AssertEquals(t, testProjectID, conflict.ProjectID)
+}
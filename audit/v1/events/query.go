@@ -0,0 +1,337 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sapcc/go-api-declarations/cadf"
+)
+
+// SortKey represents a field that events can be sorted by.
+type SortKey string
+
+// Hermes only supports sorting by the following fields. In particular,
+// initiator_type and initiator_name can be filtered on, but not sorted by.
+const (
+	SortKeyTime         SortKey = "time"
+	SortKeyObserverType SortKey = "observer_type"
+	SortKeyTargetType   SortKey = "target_type"
+	SortKeyTargetID     SortKey = "target_id"
+	SortKeyInitiatorID  SortKey = "initiator_id"
+	SortKeyAction       SortKey = "action"
+	SortKeyOutcome      SortKey = "outcome"
+	SortKeyRequestPath  SortKey = "request_path"
+)
+
+// SortKeys returns all sort keys supported by Hermes.
+func SortKeys() []SortKey {
+	return []SortKey{
+		SortKeyTime,
+		SortKeyObserverType,
+		SortKeyTargetType,
+		SortKeyTargetID,
+		SortKeyInitiatorID,
+		SortKeyAction,
+		SortKeyOutcome,
+		SortKeyRequestPath,
+	}
+}
+
+// IsValid returns whether the sort key is supported by Hermes.
+func (k SortKey) IsValid() bool {
+	return slices.Contains(SortKeys(), k)
+}
+
+// SortDirection represents the direction of a sort.
+// If no direction is specified, Hermes sorts in ascending order.
+type SortDirection string
+
+const (
+	SortAsc  SortDirection = "asc"
+	SortDesc SortDirection = "desc"
+)
+
+// IsValid returns whether the sort direction is supported by Hermes.
+func (d SortDirection) IsValid() bool {
+	return d == "" || d == SortAsc || d == SortDesc
+}
+
+// IsValid returns whether the date filter is supported by Hermes. An empty
+// filter is valid and means "equal".
+func (f DateFilter) IsValid() bool {
+	switch f {
+	case "", DateFilterGT, DateFilterGTE, DateFilterLT, DateFilterLTE:
+		return true
+	default:
+		return false
+	}
+}
+
+// Sort represents a single sort criterion.
+type Sort struct {
+	Key       SortKey
+	Direction SortDirection
+}
+
+// String formats the sort criterion in the format expected by Hermes.
+func (s Sort) String() string {
+	if s.Direction == "" {
+		return string(s.Key)
+	}
+	return fmt.Sprintf("%s:%s", s.Key, s.Direction)
+}
+
+// Query is a typed builder for ListOpts. In contrast to setting ListOpts
+// directly, the values given to a Query are validated when it is built, so
+// that unsupported sort keys, directions and time filter combinations are
+// rejected before a request is sent to Hermes. Time filters are intersected
+// into a single range, since Hermes keeps only one value per DateFilter.
+//
+// Query implements ListOptsBuilder and can be given to List directly:
+//
+//	q := events.NewQuery().
+//		ProjectID(projectID).
+//		Outcome(cadf.FailureOutcome).
+//		TimeRange(start, end).
+//		SortBy(events.SortKeyTime, events.SortDesc)
+//	pager := events.List(client, q)
+type Query struct {
+	opts  ListOpts
+	sorts []Sort
+	err   error
+}
+
+// NewQuery returns an empty Query.
+func NewQuery() *Query {
+	return &Query{}
+}
+
+// ObserverType filters events by the type URI of their observer.
+func (q *Query) ObserverType(typeURI string) *Query {
+	q.opts.ObserverType = typeURI
+	return q
+}
+
+// TargetID filters events by the ID of their target.
+func (q *Query) TargetID(id string) *Query {
+	q.opts.TargetID = id
+	return q
+}
+
+// TargetType filters events by the type URI of their target.
+func (q *Query) TargetType(typeURI string) *Query {
+	q.opts.TargetType = typeURI
+	return q
+}
+
+// InitiatorID filters events by the ID of their initiator.
+func (q *Query) InitiatorID(id string) *Query {
+	q.opts.InitiatorID = id
+	return q
+}
+
+// InitiatorType filters events by the type URI of their initiator.
+func (q *Query) InitiatorType(typeURI string) *Query {
+	q.opts.InitiatorType = typeURI
+	return q
+}
+
+// InitiatorName filters events by the name of their initiator.
+func (q *Query) InitiatorName(name string) *Query {
+	q.opts.InitiatorName = name
+	return q
+}
+
+// Action filters events by their CADF action, e.g. cadf.CreateAction or a
+// more specific action like "create/role_assignment".
+func (q *Query) Action(action cadf.Action) *Query {
+	q.opts.Action = string(action)
+	return q
+}
+
+// Outcome filters events by their CADF outcome.
+func (q *Query) Outcome(outcome cadf.Outcome) *Query {
+	q.opts.Outcome = string(outcome)
+	return q
+}
+
+// RequestPath filters events by the request path that caused them.
+func (q *Query) RequestPath(path string) *Query {
+	q.opts.RequestPath = path
+	return q
+}
+
+// DomainID restricts the query to events of the given domain.
+func (q *Query) DomainID(id string) *Query {
+	q.opts.DomainID = id
+	return q
+}
+
+// ProjectID restricts the query to events of the given project.
+func (q *Query) ProjectID(id string) *Query {
+	q.opts.ProjectID = id
+	return q
+}
+
+// Search performs a full-text search over the entire event body.
+func (q *Query) Search(term string) *Query {
+	q.opts.Search = term
+	return q
+}
+
+// Time adds a single time filter. Multiple time filters are intersected, so
+// that the query matches the events that satisfy all of them.
+func (q *Query) Time(filter DateFilter, date time.Time) *Query {
+	q.opts.Time = append(q.opts.Time, DateQuery{Date: date, Filter: filter})
+	return q
+}
+
+// TimeRange restricts the query to events in the half-open interval
+// [from, to). A zero time leaves the respective side of the range open. If
+// TimeRange is called several times, the query is restricted to the
+// intersection of the ranges.
+func (q *Query) TimeRange(from, to time.Time) *Query {
+	if !from.IsZero() {
+		q.Time(DateFilterGTE, from)
+	}
+	if !to.IsZero() {
+		q.Time(DateFilterLT, to)
+	}
+	return q
+}
+
+// SortBy appends a sort criterion. Criteria are applied in the order in
+// which they were added.
+func (q *Query) SortBy(key SortKey, direction SortDirection) *Query {
+	q.sorts = append(q.sorts, Sort{Key: key, Direction: direction})
+	return q
+}
+
+// Limit sets the maximum number of events per page.
+func (q *Query) Limit(limit int) *Query {
+	if limit < 0 {
+		q.setErr(fmt.Errorf("limit must not be negative, got %d", limit))
+	}
+	q.opts.Limit = limit
+	return q
+}
+
+// Offset sets the number of events to skip.
+func (q *Query) Offset(offset int) *Query {
+	if offset < 0 {
+		q.setErr(fmt.Errorf("offset must not be negative, got %d", offset))
+	}
+	q.opts.Offset = offset
+	return q
+}
+
+func (q *Query) setErr(err error) {
+	if q.err == nil {
+		q.err = err
+	}
+}
+
+// Build validates the query and returns the resulting ListOpts.
+func (q *Query) Build() (ListOpts, error) {
+	if q.err != nil {
+		return ListOpts{}, q.err
+	}
+
+	opts := q.opts
+
+	sorts := make([]string, 0, len(q.sorts))
+	seenKeys := make(map[SortKey]bool, len(q.sorts))
+	for _, s := range q.sorts {
+		if !s.Key.IsValid() {
+			return ListOpts{}, fmt.Errorf("events cannot be sorted by %q", s.Key)
+		}
+		if !s.Direction.IsValid() {
+			return ListOpts{}, fmt.Errorf("invalid sort direction %q for %q", s.Direction, s.Key)
+		}
+		if seenKeys[s.Key] {
+			return ListOpts{}, fmt.Errorf("sort key %q is given more than once", s.Key)
+		}
+		seenKeys[s.Key] = true
+		sorts = append(sorts, s.String())
+	}
+	opts.Sort = strings.Join(sorts, ",")
+
+	var err error
+	opts.Time, err = intersectTime(q.opts.Time)
+	if err != nil {
+		return ListOpts{}, err
+	}
+
+	return opts, nil
+}
+
+// ToEventListQuery validates the query and formats it into a query string.
+func (q *Query) ToEventListQuery() (string, error) {
+	opts, err := q.Build()
+	if err != nil {
+		return "", err
+	}
+	return opts.ToEventListQuery()
+}
+
+// intersectTime combines the given time filters into the range that
+// satisfies all of them, and returns it as at most one lower and one upper
+// bound. Hermes keeps only one value per DateFilter in the "time" query
+// parameter, so sending several filters of the same kind would silently drop
+// all but one of them.
+func intersectTime(dates []DateQuery) ([]DateQuery, error) {
+	var lower, upper *DateQuery
+	for _, dq := range dates {
+		if !dq.Filter.IsValid() {
+			return nil, fmt.Errorf("invalid time filter %q", dq.Filter)
+		}
+		switch dq.Filter {
+		case DateFilterGT, DateFilterGTE:
+			lower = tighterBound(lower, dq, DateFilterGT, 1)
+		case DateFilterLT, DateFilterLTE:
+			upper = tighterBound(upper, dq, DateFilterLT, -1)
+		case "":
+			// an equality filter is the range [Date, Date]
+			lower = tighterBound(lower, DateQuery{Date: dq.Date, Filter: DateFilterGTE}, DateFilterGT, 1)
+			upper = tighterBound(upper, DateQuery{Date: dq.Date, Filter: DateFilterLTE}, DateFilterLT, -1)
+		}
+	}
+
+	var result []DateQuery
+	if lower != nil {
+		result = append(result, *lower)
+	}
+	if upper != nil {
+		result = append(result, *upper)
+	}
+	if lower == nil || upper == nil {
+		return result, nil
+	}
+
+	inclusive := lower.Filter == DateFilterGTE && upper.Filter == DateFilterLTE
+	if upper.Date.Before(lower.Date) || (!inclusive && !lower.Date.Before(upper.Date)) {
+		return nil, errors.New("time filters describe an empty range")
+	}
+	return result, nil
+}
+
+// tighterBound returns the tighter one of the current bound and the given
+// candidate. A direction of 1 means that later dates are tighter (for lower
+// bounds), -1 that earlier dates are tighter (for upper bounds). At the same
+// date, the exclusive filter is tighter.
+func tighterBound(current *DateQuery, candidate DateQuery, exclusive DateFilter, direction int) *DateQuery {
+	if current == nil {
+		return &candidate
+	}
+	c := candidate.Date.Compare(current.Date) * direction
+	if c > 0 || (c == 0 && candidate.Filter == exclusive) {
+		return &candidate
+	}
+	return current
+}
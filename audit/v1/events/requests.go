@@ -62,7 +62,8 @@ type ListOpts struct {
 	// Search is over the entire event body.
 	Search string `q:"search"`
 
-	// Sort will sort the results in the requested order.
+	// Sort will sort the results in the requested order, e.g. "time:desc".
+	// Use Query to build a validated sort order.
 	Sort string `q:"sort"`
 
 	Limit  int `q:"limit"`
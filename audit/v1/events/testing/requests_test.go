@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/gophercloud/gophercloud/v2/pagination"
 	th "github.com/gophercloud/gophercloud/v2/testhelper"
@@ -140,3 +141,85 @@ func TestGet(t *testing.T) {
 
 	th.AssertDeepEquals(t, *n, event)
 }
+
+func TestQuery(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	query, err := events.NewQuery().
+		ProjectID("ba8304b657fb4568addf7116f41b4a16").
+		Action(cadf.CreateAction).
+		Outcome(cadf.FailureOutcome).
+		TimeRange(start, end).
+		SortBy(events.SortKeyTime, events.SortDesc).
+		SortBy(events.SortKeyAction, "").
+		Limit(10).
+		ToEventListQuery()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "?action=create&limit=10&outcome=failure&project_id=ba8304b657fb4568addf7116f41b4a16&sort=time%3Adesc%2Caction&time=gte%3A2026-01-01T00%3A00%3A00Z%2Clt%3A2026-02-01T00%3A00%3A00Z", query)
+}
+
+func TestQueryValidation(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	invalid := map[string]*events.Query{
+		"unsortable key":       events.NewQuery().SortBy("initiator_name", events.SortAsc),
+		"invalid direction":    events.NewQuery().SortBy(events.SortKeyTime, "up"),
+		"duplicate sort key":   events.NewQuery().SortBy(events.SortKeyTime, events.SortAsc).SortBy(events.SortKeyTime, events.SortDesc),
+		"invalid time filter":  events.NewQuery().Time("eq", start),
+		"disjoint ranges":      events.NewQuery().TimeRange(start, end).TimeRange(end, end.AddDate(0, 1, 0)),
+		"empty range":          events.NewQuery().TimeRange(end, start),
+		"exclusive same bound": events.NewQuery().Time(events.DateFilterGT, start).Time(events.DateFilterLTE, start),
+		"negative limit":       events.NewQuery().Limit(-1),
+		"negative offset":      events.NewQuery().Offset(-1),
+	}
+	for name, q := range invalid {
+		_, err := q.ToEventListQuery()
+		if err == nil {
+			t.Errorf("%s: expected an error, got none", name)
+		}
+	}
+
+	_, err := events.NewQuery().Time("", start).ToEventListQuery()
+	th.AssertNoErr(t, err)
+	_, err = events.NewQuery().Time(events.DateFilterGTE, start).Time(events.DateFilterLTE, start).ToEventListQuery()
+	th.AssertNoErr(t, err)
+}
+
+func TestQueryTimeIntersection(t *testing.T) {
+	jan := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	mar := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	mid := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	// two ranges are intersected into one
+	opts, err := events.NewQuery().TimeRange(jan, feb).TimeRange(mid, mar).Build()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []events.DateQuery{
+		{Date: mid, Filter: events.DateFilterGTE},
+		{Date: feb, Filter: events.DateFilterLT},
+	}, opts.Time)
+	query, err := opts.ToEventListQuery()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "?time=gte%3A2026-01-15T00%3A00%3A00Z%2Clt%3A2026-02-01T00%3A00%3A00Z", query)
+
+	// at the same date, the exclusive filter is the tighter one
+	opts, err = events.NewQuery().
+		Time(events.DateFilterGT, jan).Time(events.DateFilterGTE, jan).
+		Time(events.DateFilterLTE, feb).Time(events.DateFilterLT, feb).
+		Build()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []events.DateQuery{
+		{Date: jan, Filter: events.DateFilterGT},
+		{Date: feb, Filter: events.DateFilterLT},
+	}, opts.Time)
+
+	// an equality filter within a range narrows the range to that date
+	opts, err = events.NewQuery().TimeRange(jan, feb).Time("", mid).Build()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []events.DateQuery{
+		{Date: mid, Filter: events.DateFilterGTE},
+		{Date: mid, Filter: events.DateFilterLTE},
+	}, opts.Time)
+}
@@ -0,0 +1,245 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+// Package costestimate estimates the cost of a project before billing runs,
+// by combining the quota and usage reported by Limes with the prices from
+// billing masterdata.
+//
+// Limes resources and rates are mapped to billing metric types with a
+// configurable list of Mappings. Prices are charged per billing unit and
+// price period, so the cost of a resource is its amount multiplied with the
+// price and the number of periods in Opts.Periods. Rates count events, and
+// their usage in Limes already covers the whole estimated period, so their
+// cost is the amount multiplied with the price.
+//
+// If the price of a mapped resource or rate cannot be determined, the item is
+// left out of the estimate and the problem is reported in the returned error.
+// The partial estimate is returned nevertheless.
+package costestimate
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/sapcc/go-api-declarations/limes"
+	limesrates "github.com/sapcc/go-api-declarations/limes/rates"
+	limesresources "github.com/sapcc/go-api-declarations/limes/resources"
+
+	"github.com/sapcc/gophercloud-sapcc/v2/billing/masterdata/price"
+	rateprojects "github.com/sapcc/gophercloud-sapcc/v2/rates/v1/projects"
+	resourceprojects "github.com/sapcc/gophercloud-sapcc/v2/resources/v1/projects"
+)
+
+// Quantity selects which value of a Limes resource is charged.
+type Quantity string
+
+const (
+	// QuantityUsage charges the usage of a resource. This is the default.
+	QuantityUsage Quantity = "usage"
+	// QuantityQuota charges the quota of a resource.
+	QuantityQuota Quantity = "quota"
+)
+
+// Mapping maps a Limes resource or rate to a billing metric type.
+type Mapping struct {
+	ServiceType limes.ServiceType
+	// Name is the name of the resource or rate within the service.
+	Name string
+	// MetricType is the METRIC_TYPE of the billing prices.
+	MetricType string
+	// Factor converts an amount in the Limes unit into billing units, e.g.
+	// 1.0/1024 if Limes reports MiB and billing charges GiB. If zero, a
+	// factor of 1 is used.
+	Factor float64
+	// Quantity selects whether usage or quota is charged. It is ignored for
+	// rates, which only have a usage.
+	Quantity Quantity
+}
+
+// Opts configures an estimation.
+type Opts struct {
+	// Mappings lists the resources and rates that are charged. Resources and
+	// rates without a mapping are reported in Estimate.Unmapped.
+	Mappings []Mapping
+	// Region and ProjectType select the prices, see price.Key.
+	Region      string
+	ProjectType string
+	// Time is the point in time for which prices are looked up. If zero, the
+	// current time is used.
+	Time time.Time
+	// Periods is the number of price periods that the estimate covers, e.g.
+	// 720 for a month of 30 days if prices are per hour. It must be positive
+	// for estimating resources and is ignored for rates.
+	Periods float64
+}
+
+// Item is the estimated cost of a single resource or rate.
+type Item struct {
+	ServiceType limes.ServiceType `json:"service_type"`
+	Name        string            `json:"name"`
+	MetricType  string            `json:"metric_type"`
+	// Amount is the charged amount in billing units.
+	Amount float64 `json:"amount"`
+	// PriceLoc and PriceSec are the unit prices in local and secondary
+	// currency.
+	PriceLoc float64 `json:"price_loc"`
+	PriceSec float64 `json:"price_sec"`
+	// Periods is the number of price periods that were charged. It is 1 for
+	// rates.
+	Periods float64 `json:"periods"`
+	// CostLoc and CostSec are Amount and Periods multiplied with the unit
+	// prices.
+	CostLoc float64 `json:"cost_loc"`
+	CostSec float64 `json:"cost_sec"`
+}
+
+// Estimate is the estimated cost of a project.
+type Estimate struct {
+	ProjectID string `json:"project_id"`
+	// Items are sorted by service type and name.
+	Items    []Item  `json:"items"`
+	TotalLoc float64 `json:"total_loc"`
+	TotalSec float64 `json:"total_sec"`
+	// Unmapped lists the resources and rates without a mapping as
+	// "service_type/name".
+	Unmapped []string `json:"unmapped,omitempty"`
+}
+
+// amount is a Limes value that shall be charged.
+type amount struct {
+	serviceType limes.ServiceType
+	name        string
+	quota       *uint64
+	usage       float64
+	periods     float64
+}
+
+// EstimateProject reads the resources of a project from Limes with
+// resources/v1/projects.Get and estimates their cost.
+func EstimateProject(ctx context.Context, limesClient *gophercloud.ServiceClient, book *price.PriceBook, domainID, projectID string, opts Opts) (Estimate, error) {
+	report, err := resourceprojects.Get(ctx, limesClient, domainID, projectID, nil).Extract()
+	if err != nil {
+		return Estimate{}, err
+	}
+	return FromResources(book, *report, opts)
+}
+
+// EstimateProjectRates reads the rates of a project from Limes with
+// rates/v1/projects.Get and estimates their cost.
+func EstimateProjectRates(ctx context.Context, limesClient *gophercloud.ServiceClient, book *price.PriceBook, domainID, projectID string, opts Opts) (Estimate, error) {
+	report, err := rateprojects.Get(ctx, limesClient, domainID, projectID, nil).Extract()
+	if err != nil {
+		return Estimate{}, err
+	}
+	return FromRates(book, *report, opts)
+}
+
+// FromResources estimates the cost of the resources in a Limes project
+// report.
+func FromResources(book *price.PriceBook, report limesresources.ProjectReport, opts Opts) (Estimate, error) {
+	if opts.Periods <= 0 {
+		return Estimate{}, fmt.Errorf("cannot estimate resources for %g price periods", opts.Periods)
+	}
+	var amounts []amount
+	for serviceType, srv := range report.Services {
+		for name, res := range srv.Resources {
+			amounts = append(amounts, amount{
+				serviceType: serviceType,
+				name:        string(name),
+				quota:       res.Quota,
+				usage:       float64(res.Usage),
+				periods:     opts.Periods,
+			})
+		}
+	}
+	return estimate(book, report.UUID, amounts, opts)
+}
+
+// FromRates estimates the cost of the rates in a Limes project report.
+func FromRates(book *price.PriceBook, report limesrates.ProjectReport, opts Opts) (Estimate, error) {
+	var amounts []amount
+	for serviceType, srv := range report.Services {
+		for name, rate := range srv.Rates {
+			var usage float64
+			if rate.UsageAsBigint != "" {
+				var err error
+				usage, err = strconv.ParseFloat(rate.UsageAsBigint, 64)
+				if err != nil {
+					return Estimate{}, fmt.Errorf("invalid usage of rate %s/%s: %w", serviceType, name, err)
+				}
+			}
+			amounts = append(amounts, amount{
+				serviceType: serviceType,
+				name:        string(name),
+				usage:       usage,
+				periods:     1,
+			})
+		}
+	}
+	return estimate(book, report.UUID, amounts, opts)
+}
+
+func estimate(book *price.PriceBook, projectID string, amounts []amount, opts Opts) (Estimate, error) {
+	slices.SortFunc(amounts, func(lhs, rhs amount) int {
+		return cmp.Or(cmp.Compare(lhs.serviceType, rhs.serviceType), cmp.Compare(lhs.name, rhs.name))
+	})
+	t := opts.Time
+	if t.IsZero() {
+		t = time.Now()
+	}
+
+	result := Estimate{ProjectID: projectID}
+	var errs []error
+	for _, a := range amounts {
+		idx := slices.IndexFunc(opts.Mappings, func(m Mapping) bool {
+			return m.ServiceType == a.serviceType && m.Name == a.name
+		})
+		if idx < 0 {
+			result.Unmapped = append(result.Unmapped, fmt.Sprintf("%s/%s", a.serviceType, a.name))
+			continue
+		}
+		m := opts.Mappings[idx]
+
+		value := a.usage
+		if m.Quantity == QuantityQuota {
+			if a.quota == nil {
+				errs = append(errs, fmt.Errorf("resource %s/%s has no quota", a.serviceType, a.name))
+				continue
+			}
+			value = float64(*a.quota)
+		}
+		factor := m.Factor
+		if factor == 0 {
+			factor = 1
+		}
+
+		key := price.Key{MetricType: m.MetricType, Region: opts.Region, ProjectType: opts.ProjectType}
+		p, err := book.Lookup(key, t)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s/%s: %w", a.serviceType, a.name, err))
+			continue
+		}
+
+		item := Item{
+			ServiceType: a.serviceType,
+			Name:        a.name,
+			MetricType:  m.MetricType,
+			Amount:      value * factor,
+			PriceLoc:    p.PriceLoc,
+			PriceSec:    p.PriceSec,
+			Periods:     a.periods,
+		}
+		item.CostLoc = item.Amount * item.Periods * item.PriceLoc
+		item.CostSec = item.Amount * item.Periods * item.PriceSec
+		result.Items = append(result.Items, item)
+		result.TotalLoc += item.CostLoc
+		result.TotalSec += item.CostSec
+	}
+	return result, errors.Join(errs...)
+}
@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package testing
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+	"github.com/gophercloud/gophercloud/v2/testhelper/client"
+	limesresources "github.com/sapcc/go-api-declarations/limes/resources"
+
+	"github.com/sapcc/gophercloud-sapcc/v2/billing/costestimate"
+	"github.com/sapcc/gophercloud-sapcc/v2/billing/masterdata/price"
+)
+
+var priceBook = price.NewPriceBook([]price.Price{
+	{
+		MetricType: "compute_cores", Region: "region", ValidForProjectType: "quota",
+		PriceLoc: 2, PriceSec: 2.5,
+		ValidFrom: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+		ValidTo:   time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		MetricType: "compute_ram_gib", Region: "region", ValidForProjectType: "quota",
+		PriceLoc: 0.5, PriceSec: 0.75,
+		ValidFrom: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+		ValidTo:   time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		MetricType: "objectstore_requests", Region: "region", ValidForProjectType: "quota",
+		PriceLoc: 0.01, PriceSec: 0.02,
+		ValidFrom: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+		ValidTo:   time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC),
+	},
+})
+
+var estimateOpts = costestimate.Opts{
+	Mappings: []costestimate.Mapping{
+		{ServiceType: "compute", Name: "cores", MetricType: "compute_cores", Quantity: costestimate.QuantityQuota},
+		{ServiceType: "compute", Name: "ram", MetricType: "compute_ram_gib", Factor: 1.0 / 1024},
+		{ServiceType: "object-store", Name: "capacity", MetricType: "objectstore_capacity"},
+		{ServiceType: "object-store", Name: "object/create", MetricType: "objectstore_requests"},
+	},
+	Region:      "region",
+	ProjectType: "quota",
+	Time:        time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC),
+	Periods:     2,
+}
+
+func TestEstimateProject(t *testing.T) {
+	fakeServer := th.SetupHTTP()
+	defer fakeServer.Teardown()
+
+	fakeServer.Mux.HandleFunc("/domains/d-1/projects/p-1", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, http.MethodGet)
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, ResourcesGetResponse)
+	})
+
+	actual, err := costestimate.EstimateProject(t.Context(), client.ServiceClient(fakeServer), priceBook, "d-1", "p-1", estimateOpts)
+
+	// there is no price for the object storage capacity
+	var notFound price.PriceNotFoundError
+	th.AssertEquals(t, true, errors.As(err, &notFound))
+	th.AssertEquals(t, "objectstore_capacity", notFound.Key.MetricType)
+
+	expected := costestimate.Estimate{
+		ProjectID: "p-1",
+		Items: []costestimate.Item{
+			{
+				ServiceType: "compute", Name: "cores", MetricType: "compute_cores",
+				Amount: 20, PriceLoc: 2, PriceSec: 2.5, Periods: 2, CostLoc: 80, CostSec: 100,
+			},
+			{
+				ServiceType: "compute", Name: "ram", MetricType: "compute_ram_gib",
+				Amount: 16, PriceLoc: 0.5, PriceSec: 0.75, Periods: 2, CostLoc: 16, CostSec: 24,
+			},
+		},
+		TotalLoc: 96,
+		TotalSec: 124,
+		Unmapped: []string{"compute/instances"},
+	}
+	th.CheckDeepEquals(t, expected, actual)
+}
+
+func TestEstimateProjectRates(t *testing.T) {
+	fakeServer := th.SetupHTTP()
+	defer fakeServer.Teardown()
+
+	fakeServer.Mux.HandleFunc("/domains/d-1/projects/p-1", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, http.MethodGet)
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, RatesGetResponse)
+	})
+
+	actual, err := costestimate.EstimateProjectRates(t.Context(), client.ServiceClient(fakeServer), priceBook, "d-1", "p-1", estimateOpts)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, len(actual.Items))
+	th.AssertEquals(t, 1500.0, actual.Items[0].Amount)
+	// rates are not multiplied with Periods
+	th.AssertEquals(t, 1.0, actual.Items[0].Periods)
+	th.AssertEquals(t, 15.0, actual.TotalLoc)
+	th.AssertEquals(t, 30.0, actual.TotalSec)
+}
+
+func TestEstimateWithoutPeriods(t *testing.T) {
+	opts := estimateOpts
+	opts.Periods = 0
+	_, err := costestimate.FromResources(priceBook, limesresources.ProjectReport{}, opts)
+	th.AssertErr(t, err)
+}
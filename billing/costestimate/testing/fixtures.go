@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package testing
+
+const ResourcesGetResponse = `
+{
+  "project": {
+    "id": "p-1",
+    "name": "project",
+    "parent_id": "d-1",
+    "services": [
+      {
+        "type": "compute",
+        "area": "compute",
+        "resources": [
+          {"name": "cores", "quota": 20, "usage": 8},
+          {"name": "ram", "unit": "MiB", "quota": 40960, "usage": 16384},
+          {"name": "instances", "quota": 10, "usage": 4}
+        ]
+      },
+      {
+        "type": "object-store",
+        "area": "storage",
+        "resources": [
+          {"name": "capacity", "unit": "B", "quota": 1073741824, "usage": 0}
+        ]
+      }
+    ]
+  }
+}
+`
+
+const RatesGetResponse = `
+{
+  "project": {
+    "id": "p-1",
+    "name": "project",
+    "parent_id": "d-1",
+    "services": [
+      {
+        "type": "object-store",
+        "area": "storage",
+        "rates": [
+          {"name": "object/create", "usage_as_bigint": "1500"}
+        ]
+      }
+    ]
+  }
+}
+`
@@ -0,0 +1,297 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cache keeps a local copy of the project and domain masterdata that
+// is kept up to date with incremental syncs.
+//
+// The first sync loads all projects and domains. Later syncs only list the
+// objects that changed since the latest ChangedAt seen, using the From option
+// of projects.ListOpts and domains.ListOpts. Deleted objects are detected by
+// listing the changes twice, with and without ExcludeDeleted: objects that
+// only appear in the former have been deleted.
+package cache
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2"
+
+	"github.com/sapcc/gophercloud-sapcc/v2/billing/masterdata/domains"
+	"github.com/sapcc/gophercloud-sapcc/v2/billing/masterdata/projects"
+)
+
+// Opts configures a Cache.
+type Opts struct {
+	// Store persists the cache between program runs. If nil, the cache is
+	// only kept in memory.
+	Store Store
+	// FullSyncInterval forces a full sync if the last full sync is older. If
+	// zero, only the first sync is a full sync.
+	FullSyncInterval time.Duration
+}
+
+// Cache is a local copy of the project and domain masterdata. It is safe for
+// concurrent use. Reads are not blocked while Sync fetches changes.
+type Cache struct {
+	client *gophercloud.ServiceClient
+	opts   Opts
+
+	// syncMutex serializes calls of Sync. It is held while fetching, whereas
+	// mutex is only held to swap in the results.
+	syncMutex sync.Mutex
+	loaded    bool
+
+	mutex    sync.RWMutex
+	snapshot Snapshot
+	index
+}
+
+// index contains the lookup maps into a snapshot.
+type index struct {
+	projects     map[string]projects.Project
+	domains      map[string]domains.Domain
+	byDomain     map[string][]string
+	byCostObject map[string][]string
+}
+
+// New returns an empty Cache that reads masterdata with the given billing
+// client. Call Sync to fill it.
+func New(client *gophercloud.ServiceClient, opts Opts) *Cache {
+	return &Cache{client: client, opts: opts, index: newIndex(Snapshot{})}
+}
+
+// Sync brings the cache up to date. On the first call, the snapshot from the
+// Store is loaded, if any. A full sync is done if there is no snapshot yet or
+// the last full sync is older than Opts.FullSyncInterval; otherwise only the
+// changes are fetched. The updated snapshot is saved to the Store.
+func (c *Cache) Sync(ctx context.Context) error {
+	c.syncMutex.Lock()
+	defer c.syncMutex.Unlock()
+
+	if !c.loaded && c.opts.Store != nil {
+		s, exists, err := c.opts.Store.Load(ctx)
+		if err != nil {
+			return err
+		}
+		if exists {
+			c.swap(s)
+		}
+	}
+	c.loaded = true
+
+	// only Sync replaces the snapshot, so it can be read without holding
+	// the mutex while syncMutex is held
+	current := c.snapshot
+	full := current.FullSyncAt.IsZero() ||
+		(c.opts.FullSyncInterval > 0 && time.Since(current.FullSyncAt) > c.opts.FullSyncInterval)
+	var (
+		next Snapshot
+		err  error
+	)
+	if full {
+		next, err = c.fullSync(ctx)
+	} else {
+		next, err = c.incrementalSync(ctx, current)
+	}
+	if err != nil {
+		return err
+	}
+
+	c.swap(next)
+	if c.opts.Store != nil {
+		return c.opts.Store.Save(ctx, next)
+	}
+	return nil
+}
+
+// swap builds the index of the snapshot and replaces the current state.
+func (c *Cache) swap(s Snapshot) {
+	idx := newIndex(s)
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.snapshot = s
+	c.index = idx
+}
+
+func (c *Cache) fullSync(ctx context.Context) (Snapshot, error) {
+	startedAt := time.Now()
+	allProjects, err := listProjects(ctx, c.client, projects.ListOpts{ExcludeDeleted: true})
+	if err != nil {
+		return Snapshot{}, err
+	}
+	allDomains, err := listDomains(ctx, c.client, domains.ListOpts{ExcludeDeleted: true})
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	return Snapshot{
+		Projects:       allProjects,
+		Domains:        allDomains,
+		ProjectsCursor: latestChange(allProjects, func(p projects.Project) time.Time { return p.ChangedAt }),
+		DomainsCursor:  latestChange(allDomains, func(d domains.Domain) time.Time { return d.ChangedAt }),
+		FullSyncAt:     startedAt,
+	}, nil
+}
+
+func (c *Cache) incrementalSync(ctx context.Context, s Snapshot) (Snapshot, error) {
+	from := s.ProjectsCursor
+	changed, err := listProjects(ctx, c.client, projects.ListOpts{From: from})
+	if err != nil {
+		return Snapshot{}, err
+	}
+	live, err := listProjects(ctx, c.client, projects.ListOpts{From: from, ExcludeDeleted: true})
+	if err != nil {
+		return Snapshot{}, err
+	}
+	s.Projects = merge(s.Projects, changed, live, func(p projects.Project) string { return p.ProjectID })
+	if t := latestChange(changed, func(p projects.Project) time.Time { return p.ChangedAt }); t.After(from) {
+		s.ProjectsCursor = t
+	}
+
+	from = s.DomainsCursor
+	changedDomains, err := listDomains(ctx, c.client, domains.ListOpts{From: from})
+	if err != nil {
+		return Snapshot{}, err
+	}
+	liveDomains, err := listDomains(ctx, c.client, domains.ListOpts{From: from, ExcludeDeleted: true})
+	if err != nil {
+		return Snapshot{}, err
+	}
+	s.Domains = merge(s.Domains, changedDomains, liveDomains, func(d domains.Domain) string { return d.DomainID })
+	if t := latestChange(changedDomains, func(d domains.Domain) time.Time { return d.ChangedAt }); t.After(from) {
+		s.DomainsCursor = t
+	}
+	return s, nil
+}
+
+// merge applies the changes to the current objects: objects in live are
+// inserted or replaced, objects that are only in changed are removed.
+func merge[T any](current, changed, live []T, id func(T) string) []T {
+	byID := make(map[string]T, len(current))
+	for _, obj := range current {
+		byID[id(obj)] = obj
+	}
+	for _, obj := range changed {
+		delete(byID, id(obj))
+	}
+	for _, obj := range live {
+		byID[id(obj)] = obj
+	}
+	return slices.SortedFunc(maps.Values(byID), func(lhs, rhs T) int { return cmp.Compare(id(lhs), id(rhs)) })
+}
+
+func latestChange[T any](objs []T, changedAt func(T) time.Time) time.Time {
+	var result time.Time
+	for _, obj := range objs {
+		if t := changedAt(obj); t.After(result) {
+			result = t
+		}
+	}
+	return result
+}
+
+func listProjects(ctx context.Context, client *gophercloud.ServiceClient, opts projects.ListOpts) ([]projects.Project, error) {
+	page, err := projects.List(client, opts).AllPages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return projects.ExtractProjects(page)
+}
+
+func listDomains(ctx context.Context, client *gophercloud.ServiceClient, opts domains.ListOpts) ([]domains.Domain, error) {
+	page, err := domains.List(client, opts).AllPages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return domains.ExtractDomains(page)
+}
+
+// newIndex builds the lookup maps for the snapshot.
+func newIndex(s Snapshot) index {
+	idx := index{
+		projects:     make(map[string]projects.Project, len(s.Projects)),
+		domains:      make(map[string]domains.Domain, len(s.Domains)),
+		byDomain:     make(map[string][]string),
+		byCostObject: make(map[string][]string),
+	}
+	for _, d := range s.Domains {
+		idx.domains[d.DomainID] = d
+	}
+	for _, p := range s.Projects {
+		idx.projects[p.ProjectID] = p
+		idx.byDomain[p.DomainID] = append(idx.byDomain[p.DomainID], p.ProjectID)
+		if name := idx.costObjectName(p); name != "" {
+			idx.byCostObject[name] = append(idx.byCostObject[name], p.ProjectID)
+		}
+	}
+	return idx
+}
+
+// costObjectName returns the name of the effective cost object of the
+// project, or an empty string if it cannot be determined from the index.
+func (idx index) costObjectName(p projects.Project) string {
+	if !p.CostObject.Inherited {
+		return p.CostObject.Name
+	}
+	d, exists := idx.domains[p.DomainID]
+	if !exists || !d.CostObject.ProjectsCanInherit {
+		return ""
+	}
+	return d.CostObject.Name
+}
+
+// Snapshot returns a copy of the current state of the cache.
+func (c *Cache) Snapshot() Snapshot {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	s := c.snapshot
+	s.Projects = slices.Clone(s.Projects)
+	s.Domains = slices.Clone(s.Domains)
+	return s
+}
+
+// Project returns the project with the given ID.
+func (c *Cache) Project(projectID string) (projects.Project, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	p, exists := c.projects[projectID]
+	return p, exists
+}
+
+// Domain returns the domain with the given ID.
+func (c *Cache) Domain(domainID string) (domains.Domain, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	d, exists := c.domains[domainID]
+	return d, exists
+}
+
+// ProjectsInDomain returns the projects in the domain with the given ID,
+// sorted by project ID.
+func (c *Cache) ProjectsInDomain(domainID string) []projects.Project {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.lookup(c.byDomain[domainID])
+}
+
+// ProjectsByCostObject returns the projects that are charged to the cost
+// object with the given name, sorted by project ID. This includes projects
+// that inherit the cost object from their domain.
+func (c *Cache) ProjectsByCostObject(name string) []projects.Project {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.lookup(c.byCostObject[name])
+}
+
+func (c *Cache) lookup(projectIDs []string) []projects.Project {
+	result := make([]projects.Project, 0, len(projectIDs))
+	for _, id := range projectIDs {
+		result = append(result, c.projects[id])
+	}
+	return result
+}
@@ -0,0 +1,166 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"time"
+
+	"github.com/sapcc/gophercloud-sapcc/v2/billing/masterdata/domains"
+	"github.com/sapcc/gophercloud-sapcc/v2/billing/masterdata/projects"
+)
+
+// Snapshot is the persistent state of a Cache. It implements
+// json.Marshaler and json.Unmarshaler, so that a Store can persist it as
+// JSON without losing the fields of projects and domains that only have a
+// decoder for the API format.
+type Snapshot struct {
+	Projects []projects.Project
+	Domains  []domains.Domain
+	// ProjectsCursor and DomainsCursor are the latest ChangedAt values that
+	// have been seen. The next incremental sync fetches changes from there.
+	ProjectsCursor time.Time
+	DomainsCursor  time.Time
+	// FullSyncAt is the time of the last full sync.
+	FullSyncAt time.Time
+}
+
+// snapshotJSON is the JSON format of a Snapshot.
+type snapshotJSON struct {
+	Projects       []projectJSON `json:"projects"`
+	Domains        []domainJSON  `json:"domains"`
+	ProjectsCursor time.Time     `json:"projects_cursor"`
+	DomainsCursor  time.Time     `json:"domains_cursor"`
+	FullSyncAt     time.Time     `json:"full_sync_at"`
+}
+
+// projectJSON stores the fields of a project that are excluded from its JSON
+// encoding next to it.
+type projectJSON struct {
+	Project                      projects.Project `json:"project"`
+	CreatedAt                    time.Time        `json:"created_at"`
+	ChangedAt                    time.Time        `json:"changed_at"`
+	GPUEnabled                   bool             `json:"gpu_enabled"`
+	ContainsPIIDPPHR             bool             `json:"contains_pii_dpp_hr"`
+	ContainsExternalCustomerData bool             `json:"contains_external_customer_data"`
+}
+
+// domainJSON stores the fields of a domain that are excluded from its JSON
+// encoding next to it.
+type domainJSON struct {
+	Domain    domains.Domain `json:"domain"`
+	CreatedAt time.Time      `json:"created_at"`
+	ChangedAt time.Time      `json:"changed_at"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (s Snapshot) MarshalJSON() ([]byte, error) {
+	res := snapshotJSON{
+		Projects:       make([]projectJSON, 0, len(s.Projects)),
+		Domains:        make([]domainJSON, 0, len(s.Domains)),
+		ProjectsCursor: s.ProjectsCursor,
+		DomainsCursor:  s.DomainsCursor,
+		FullSyncAt:     s.FullSyncAt,
+	}
+	for _, p := range s.Projects {
+		res.Projects = append(res.Projects, projectJSON{
+			Project:                      p,
+			CreatedAt:                    p.CreatedAt,
+			ChangedAt:                    p.ChangedAt,
+			GPUEnabled:                   p.GPUEnabled,
+			ContainsPIIDPPHR:             p.ContainsPIIDPPHR,
+			ContainsExternalCustomerData: p.ContainsExternalCustomerData,
+		})
+	}
+	for _, d := range s.Domains {
+		res.Domains = append(res.Domains, domainJSON{
+			Domain:    d,
+			CreatedAt: d.CreatedAt,
+			ChangedAt: d.ChangedAt,
+		})
+	}
+	return json.Marshal(res)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (s *Snapshot) UnmarshalJSON(b []byte) error {
+	var res snapshotJSON
+	err := json.Unmarshal(b, &res)
+	if err != nil {
+		return err
+	}
+
+	*s = Snapshot{
+		Projects:       make([]projects.Project, 0, len(res.Projects)),
+		Domains:        make([]domains.Domain, 0, len(res.Domains)),
+		ProjectsCursor: res.ProjectsCursor,
+		DomainsCursor:  res.DomainsCursor,
+		FullSyncAt:     res.FullSyncAt,
+	}
+	for _, pj := range res.Projects {
+		p := pj.Project
+		p.CreatedAt = pj.CreatedAt
+		p.ChangedAt = pj.ChangedAt
+		p.GPUEnabled = pj.GPUEnabled
+		p.ContainsPIIDPPHR = pj.ContainsPIIDPPHR
+		p.ContainsExternalCustomerData = pj.ContainsExternalCustomerData
+		s.Projects = append(s.Projects, p)
+	}
+	for _, dj := range res.Domains {
+		d := dj.Domain
+		d.CreatedAt = dj.CreatedAt
+		d.ChangedAt = dj.ChangedAt
+		s.Domains = append(s.Domains, d)
+	}
+	return nil
+}
+
+// Store persists snapshots of a Cache between program runs.
+type Store interface {
+	// Load returns the last saved snapshot. If nothing has been saved yet,
+	// it returns false and no error.
+	Load(ctx context.Context) (Snapshot, bool, error)
+	// Save replaces the saved snapshot.
+	Save(ctx context.Context, s Snapshot) error
+}
+
+// FileStore is a Store that keeps the snapshot in a JSON file.
+type FileStore struct {
+	Path string
+}
+
+// Load implements the Store interface.
+func (f FileStore) Load(_ context.Context) (Snapshot, bool, error) {
+	buf, err := os.ReadFile(f.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Snapshot{}, false, nil
+	}
+	if err != nil {
+		return Snapshot{}, false, err
+	}
+	var s Snapshot
+	err = json.Unmarshal(buf, &s)
+	if err != nil {
+		return Snapshot{}, false, err
+	}
+	return s, true, nil
+}
+
+// Save implements the Store interface. The file is replaced atomically.
+func (f FileStore) Save(_ context.Context, s Snapshot) error {
+	buf, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	tmp := f.Path + ".tmp"
+	err = os.WriteFile(tmp, buf, 0o640)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp, f.Path)
+}
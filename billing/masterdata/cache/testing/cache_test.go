@@ -0,0 +1,132 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+	"github.com/gophercloud/gophercloud/v2/testhelper/client"
+
+	"github.com/sapcc/gophercloud-sapcc/v2/billing/masterdata/cache"
+	"github.com/sapcc/gophercloud-sapcc/v2/billing/masterdata/projects"
+)
+
+func projectIDs(list []projects.Project) []string {
+	result := make([]string, 0, len(list))
+	for _, p := range list {
+		result = append(result, p.ProjectID)
+	}
+	return result
+}
+
+func TestSync(t *testing.T) {
+	fakeServer := th.SetupHTTP()
+	defer fakeServer.Teardown()
+
+	var projectQueries []string
+	fakeServer.Mux.HandleFunc("/masterdata/projects", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, http.MethodGet)
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		projectQueries = append(projectQueries, r.URL.RawQuery)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		q := r.URL.Query()
+		switch {
+		case !q.Has("from"):
+			fmt.Fprint(w, FullProjectsResponse)
+		case q.Get("excludeDeleted") == "true":
+			fmt.Fprint(w, LiveProjectsResponse)
+		default:
+			fmt.Fprint(w, ChangedProjectsResponse)
+		}
+	})
+	fakeServer.Mux.HandleFunc("/masterdata/domains", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, http.MethodGet)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if r.URL.Query().Has("from") {
+			fmt.Fprint(w, "[]")
+		} else {
+			fmt.Fprint(w, FullDomainsResponse)
+		}
+	})
+
+	store := cache.FileStore{Path: filepath.Join(t.TempDir(), "masterdata.json")}
+	c := cache.New(client.ServiceClient(fakeServer), cache.Opts{Store: store})
+	th.AssertNoErr(t, c.Sync(t.Context()))
+	th.CheckDeepEquals(t, []string{"excludeDeleted=true"}, projectQueries)
+	th.CheckDeepEquals(t, []string{"p-1", "p-2"}, projectIDs(c.ProjectsInDomain("d-1")))
+	th.CheckDeepEquals(t, []string{"p-2"}, projectIDs(c.ProjectsByCostObject("co-d")))
+
+	// a new cache continues from the persisted snapshot
+	c = cache.New(client.ServiceClient(fakeServer), cache.Opts{Store: store})
+	th.AssertNoErr(t, c.Sync(t.Context()))
+	th.CheckDeepEquals(t, []string{
+		"excludeDeleted=true",
+		"from=2026-01-02T10%3A00%3A00",
+		"excludeDeleted=true&from=2026-01-02T10%3A00%3A00",
+	}, projectQueries)
+
+	_, exists := c.Project("p-2")
+	th.AssertEquals(t, false, exists)
+	p, exists := c.Project("p-1")
+	th.AssertEquals(t, true, exists)
+	th.AssertEquals(t, true, p.GPUEnabled)
+	th.AssertEquals(t, time.Date(2026, time.January, 1, 10, 0, 0, 123000000, time.UTC), p.ChangedAt)
+	d, exists := c.Domain("d-1")
+	th.AssertEquals(t, true, exists)
+	th.AssertEquals(t, "co-d", d.CostObject.Name)
+
+	th.CheckDeepEquals(t, []string{"p-1", "p-3"}, projectIDs(c.ProjectsByCostObject("co-1")))
+	th.AssertEquals(t, 0, len(c.ProjectsByCostObject("co-d")))
+	th.AssertEquals(t, time.Date(2026, time.January, 3, 9, 0, 0, 0, time.UTC), c.Snapshot().ProjectsCursor)
+}
+
+func TestReadDuringSync(t *testing.T) {
+	fakeServer := th.SetupHTTP()
+	defer fakeServer.Teardown()
+
+	calls := 0
+	started := make(chan struct{})
+	release := make(chan struct{})
+	fakeServer.Mux.HandleFunc("/masterdata/projects", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 2 {
+			// block the second full sync until the reads are done
+			close(started)
+			<-release
+		}
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, FullProjectsResponse)
+	})
+	fakeServer.Mux.HandleFunc("/masterdata/domains", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, FullDomainsResponse)
+	})
+
+	c := cache.New(client.ServiceClient(fakeServer), cache.Opts{FullSyncInterval: time.Nanosecond})
+	th.AssertNoErr(t, c.Sync(t.Context()))
+
+	done := make(chan error)
+	go func() { done <- c.Sync(t.Context()) }()
+	<-started
+
+	// reads are served from the previous snapshot while Sync is fetching
+	_, exists := c.Project("p-1")
+	th.AssertEquals(t, true, exists)
+	th.CheckDeepEquals(t, []string{"p-1", "p-2"}, projectIDs(c.ProjectsInDomain("d-1")))
+
+	close(release)
+	th.AssertNoErr(t, <-done)
+	th.AssertEquals(t, 2, calls)
+}
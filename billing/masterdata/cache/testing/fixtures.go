@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package testing
+
+const FullProjectsResponse = `
+[
+  {
+    "project_id": "p-1",
+    "project_name": "one",
+    "domain_id": "d-1",
+    "cost_object": {"name": "co-1", "type": "IO", "inherited": false},
+    "gpu_enabled": 1,
+    "changed_at": "2026-01-01T10:00:00.123"
+  },
+  {
+    "project_id": "p-2",
+    "project_name": "two",
+    "domain_id": "d-1",
+    "cost_object": {"inherited": true},
+    "changed_at": "2026-01-02T10:00:00"
+  }
+]
+`
+
+const FullDomainsResponse = `
+[
+  {
+    "domain_id": "d-1",
+    "domain_name": "domain",
+    "cost_object": {"name": "co-d", "type": "CC", "projects_can_inherit": true},
+    "changed_at": "2025-12-01T10:00:00"
+  }
+]
+`
+
+// p-2 was deleted, p-3 was created
+const ChangedProjectsResponse = `
+[
+  {
+    "project_id": "p-2",
+    "project_name": "two",
+    "domain_id": "d-1",
+    "cost_object": {"inherited": true},
+    "changed_at": "2026-01-03T08:00:00"
+  },
+  {
+    "project_id": "p-3",
+    "project_name": "three",
+    "domain_id": "d-1",
+    "cost_object": {"name": "co-1", "type": "IO", "inherited": false},
+    "changed_at": "2026-01-03T09:00:00"
+  }
+]
+`
+
+const LiveProjectsResponse = `
+[
+  {
+    "project_id": "p-3",
+    "project_name": "three",
+    "domain_id": "d-1",
+    "cost_object": {"name": "co-1", "type": "IO", "inherited": false},
+    "changed_at": "2026-01-03T09:00:00"
+  }
+]
+`
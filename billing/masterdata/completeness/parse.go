@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package completeness
+
+import (
+	"strings"
+)
+
+// MissingAttribute is a single item of Project.MissingAttributes.
+type MissingAttribute struct {
+	// Field is the JSON name of the masterdata field that the item refers
+	// to, e.g. "cost_object". It is empty if the item could not be mapped
+	// to a field.
+	Field string `json:"field,omitempty"`
+	// Text is the human-readable text of the item.
+	Text string `json:"text"`
+}
+
+// fieldKeywords maps phrases that appear in MissingAttributes to the JSON
+// names of masterdata fields. More specific phrases come first, since the
+// first match wins.
+var fieldKeywords = []struct {
+	keyword string
+	field   string
+}{
+	{"primary contact email", "responsible_primary_contact_email"},
+	{"primary contact", "responsible_primary_contact_id"},
+	{"operator email", "responsible_operator_email"},
+	{"operator", "responsible_operator_id"},
+	{"inventory role email", "responsible_inventory_role_email"},
+	{"inventory role", "responsible_inventory_role_id"},
+	{"infrastructure coordinator email", "responsible_infrastructure_coordinator_email"},
+	{"infrastructure coordinator", "responsible_infrastructure_coordinator_id"},
+	{"cost object", "cost_object"},
+	{"costobject", "cost_object"},
+	{"revenue relevance", "revenue_relevance"},
+	{"business criticality", "business_criticality"},
+	{"number of end users", "number_of_endusers"},
+	{"number of endusers", "number_of_endusers"},
+	{"soft license mode", "soft_license_mode"},
+	{"type of data", "type_of_data"},
+	{"environment", "environment"},
+	{"external customer data", "contains_external_customer_data"},
+	{"pii", "contains_pii_dpp_hr"},
+	{"gpu", "gpu_enabled"},
+	{"certification", "ext_certification"},
+	{"customer", "customer"},
+	{"description", "description"},
+}
+
+// ParseMissingAttributes splits the human-readable MissingAttributes text of
+// a project into its items, and maps each item to the masterdata field it
+// refers to. Items are separated by commas, semicolons or line breaks. Field
+// names in snake_case or camelCase are recognized as well as phrases like
+// "Primary contact not specified".
+func ParseMissingAttributes(text string) []MissingAttribute {
+	items := strings.FieldsFunc(text, func(r rune) bool {
+		return r == ',' || r == ';' || r == '\n'
+	})
+
+	var result []MissingAttribute
+	for _, item := range items {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		result = append(result, MissingAttribute{
+			Field: matchField(normalize(item)),
+			Text:  item,
+		})
+	}
+	return result
+}
+
+// normalize lowercases the text and turns snake_case and camelCase into
+// space-separated words.
+func normalize(text string) string {
+	var b strings.Builder
+	prevLower := false
+	for _, r := range text {
+		switch {
+		case r == '_' || r == '-' || r == '.' || r == ':':
+			b.WriteRune(' ')
+			prevLower = false
+		case r >= 'A' && r <= 'Z':
+			if prevLower {
+				b.WriteRune(' ')
+			}
+			b.WriteRune(r - 'A' + 'a')
+			prevLower = false
+		default:
+			b.WriteRune(r)
+			prevLower = r >= 'a' && r <= 'z'
+		}
+	}
+	return " " + strings.Join(strings.Fields(b.String()), " ") + " "
+}
+
+func matchField(normalized string) string {
+	for _, fk := range fieldKeywords {
+		if strings.Contains(normalized, " "+fk.keyword+" ") {
+			return fk.field
+		}
+	}
+	return ""
+}
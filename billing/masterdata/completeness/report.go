@@ -0,0 +1,189 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+// Package completeness reports projects with incomplete billing masterdata,
+// so that their responsible contacts can be reminded to fill in the missing
+// attributes.
+package completeness
+
+import (
+	"cmp"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/gophercloud/gophercloud/v2"
+
+	"github.com/sapcc/gophercloud-sapcc/v2/billing/masterdata/projects"
+)
+
+// Entry describes a single project with incomplete masterdata.
+type Entry struct {
+	ProjectID                      string `json:"project_id"`
+	ProjectName                    string `json:"project_name"`
+	DomainID                       string `json:"domain_id"`
+	DomainName                     string `json:"domain_name"`
+	ResponsiblePrimaryContactID    string `json:"responsible_primary_contact_id,omitempty"`
+	ResponsiblePrimaryContactEmail string `json:"responsible_primary_contact_email,omitempty"`
+	ResponsibleOperatorID          string `json:"responsible_operator_id,omitempty"`
+	ResponsibleOperatorEmail       string `json:"responsible_operator_email,omitempty"`
+	// The parsed content of Project.MissingAttributes.
+	Missing []MissingAttribute `json:"missing"`
+	// The unparsed content of Project.MissingAttributes.
+	MissingAttributes string `json:"missing_attributes"`
+}
+
+// Contacts returns the distinct email addresses of the people responsible for
+// the project.
+func (e Entry) Contacts() []string {
+	var result []string
+	for _, email := range []string{e.ResponsiblePrimaryContactEmail, e.ResponsibleOperatorEmail} {
+		if email != "" && !slices.Contains(result, email) {
+			result = append(result, email)
+		}
+	}
+	return result
+}
+
+// Group is a set of entries that share a common key, e.g. a domain ID or a
+// contact email address.
+type Group struct {
+	Key     string  `json:"key"`
+	Entries []Entry `json:"entries"`
+}
+
+// Report lists all projects with incomplete masterdata, sorted by domain name
+// and project name.
+type Report struct {
+	Entries []Entry `json:"entries"`
+}
+
+// Generate lists all projects with the given options and returns a Report of
+// those that are not complete. Deleted projects are only excluded if
+// opts.ExcludeDeleted is set; if opts.CheckCOValidity is set, the server also
+// treats invalid cost objects as missing attributes.
+func Generate(ctx context.Context, client *gophercloud.ServiceClient, opts projects.ListOpts) (Report, error) {
+	page, err := projects.List(client, opts).AllPages(ctx)
+	if err != nil {
+		return Report{}, err
+	}
+	allProjects, err := projects.ExtractProjects(page)
+	if err != nil {
+		return Report{}, err
+	}
+	return NewReport(allProjects), nil
+}
+
+// NewReport builds a Report from the given projects. Complete projects are
+// skipped.
+func NewReport(allProjects []projects.Project) Report {
+	var r Report
+	for _, p := range allProjects {
+		if p.IsComplete {
+			continue
+		}
+		r.Entries = append(r.Entries, Entry{
+			ProjectID:                      p.ProjectID,
+			ProjectName:                    p.ProjectName,
+			DomainID:                       p.DomainID,
+			DomainName:                     p.DomainName,
+			ResponsiblePrimaryContactID:    p.ResponsiblePrimaryContactID,
+			ResponsiblePrimaryContactEmail: p.ResponsiblePrimaryContactEmail,
+			ResponsibleOperatorID:          p.ResponsibleOperatorID,
+			ResponsibleOperatorEmail:       p.ResponsibleOperatorEmail,
+			Missing:                        ParseMissingAttributes(p.MissingAttributes),
+			MissingAttributes:              p.MissingAttributes,
+		})
+	}
+	slices.SortFunc(r.Entries, func(lhs, rhs Entry) int {
+		return cmp.Or(
+			cmp.Compare(lhs.DomainName, rhs.DomainName),
+			cmp.Compare(lhs.ProjectName, rhs.ProjectName),
+			cmp.Compare(lhs.ProjectID, rhs.ProjectID),
+		)
+	})
+	return r
+}
+
+// ByDomain groups the entries by domain ID.
+func (r Report) ByDomain() []Group {
+	return r.groupBy(func(e Entry) []string { return []string{e.DomainID} })
+}
+
+// ByContact groups the entries by the email addresses of their responsible
+// contacts. An entry with several contacts appears in several groups. Entries
+// without any contact are grouped under the empty key.
+func (r Report) ByContact() []Group {
+	return r.groupBy(func(e Entry) []string {
+		contacts := e.Contacts()
+		if len(contacts) == 0 {
+			return []string{""}
+		}
+		return contacts
+	})
+}
+
+func (r Report) groupBy(keys func(Entry) []string) []Group {
+	index := make(map[string]int)
+	var groups []Group
+	for _, e := range r.Entries {
+		for _, key := range keys(e) {
+			idx, exists := index[key]
+			if !exists {
+				idx = len(groups)
+				index[key] = idx
+				groups = append(groups, Group{Key: key})
+			}
+			groups[idx].Entries = append(groups[idx].Entries, e)
+		}
+	}
+	slices.SortFunc(groups, func(lhs, rhs Group) int { return cmp.Compare(lhs.Key, rhs.Key) })
+	return groups
+}
+
+// WriteJSON writes the report as a JSON document.
+func (r Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// csvHeader contains the column names written by WriteCSV.
+var csvHeader = []string{
+	"domain_id", "domain_name", "project_id", "project_name",
+	"responsible_primary_contact_id", "responsible_primary_contact_email",
+	"responsible_operator_id", "responsible_operator_email",
+	"missing_fields", "missing_attributes",
+}
+
+// WriteCSV writes the report as CSV with a header row. The missing_fields
+// column contains the parsed field names separated by spaces.
+func (r Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	err := cw.Write(csvHeader)
+	if err != nil {
+		return err
+	}
+	for _, e := range r.Entries {
+		fields := make([]string, 0, len(e.Missing))
+		for _, m := range e.Missing {
+			if m.Field != "" {
+				fields = append(fields, m.Field)
+			}
+		}
+		err = cw.Write([]string{
+			e.DomainID, e.DomainName, e.ProjectID, e.ProjectName,
+			e.ResponsiblePrimaryContactID, e.ResponsiblePrimaryContactEmail,
+			e.ResponsibleOperatorID, e.ResponsibleOperatorEmail,
+			strings.Join(fields, " "), e.MissingAttributes,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
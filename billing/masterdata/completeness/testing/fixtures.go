@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package testing
+
+const ListResponse = `
+[
+  {
+    "project_id": "p1",
+    "project_name": "complete",
+    "domain_id": "d1",
+    "domain_name": "alpha",
+    "responsible_primary_contact_email": "alice@mail.com",
+    "is_complete": true
+  },
+  {
+    "project_id": "p2",
+    "project_name": "no-co",
+    "domain_id": "d1",
+    "domain_name": "alpha",
+    "responsible_primary_contact_email": "alice@mail.com",
+    "responsible_operator_email": "ops@mail.com",
+    "is_complete": false,
+    "missing_attributes": "Cost object not valid, Revenue relevance not specified"
+  },
+  {
+    "project_id": "p3",
+    "project_name": "orphan",
+    "domain_id": "d0",
+    "domain_name": "aardvark",
+    "is_complete": false,
+    "missing_attributes": "Primary contact not specified; businessCriticality; something else"
+  }
+]
+`
+
+const ExpectedCSV = `domain_id,domain_name,project_id,project_name,responsible_primary_contact_id,responsible_primary_contact_email,responsible_operator_id,responsible_operator_email,missing_fields,missing_attributes
+d0,aardvark,p3,orphan,,,,,responsible_primary_contact_id business_criticality,Primary contact not specified; businessCriticality; something else
+d1,alpha,p2,no-co,,alice@mail.com,,ops@mail.com,cost_object revenue_relevance,"Cost object not valid, Revenue relevance not specified"
+`
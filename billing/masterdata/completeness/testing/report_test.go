@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package testing
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+	"github.com/gophercloud/gophercloud/v2/testhelper/client"
+
+	"github.com/sapcc/gophercloud-sapcc/v2/billing/masterdata/completeness"
+	"github.com/sapcc/gophercloud-sapcc/v2/billing/masterdata/projects"
+)
+
+func TestParseMissingAttributes(t *testing.T) {
+	actual := completeness.ParseMissingAttributes("Primary contact not specified, cost_object;responsibleOperatorEmail\nfoo bar, ")
+	th.CheckDeepEquals(t, []completeness.MissingAttribute{
+		{Field: "responsible_primary_contact_id", Text: "Primary contact not specified"},
+		{Field: "cost_object", Text: "cost_object"},
+		{Field: "responsible_operator_email", Text: "responsibleOperatorEmail"},
+		{Text: "foo bar"},
+	}, actual)
+
+	th.AssertEquals(t, 0, len(completeness.ParseMissingAttributes("")))
+}
+
+func TestGenerate(t *testing.T) {
+	fakeServer := th.SetupHTTP()
+	defer fakeServer.Teardown()
+
+	fakeServer.Mux.HandleFunc("/masterdata/projects", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, http.MethodGet)
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		th.CheckEquals(t, "true", r.URL.Query().Get("checkCOValidity"))
+		th.CheckEquals(t, "true", r.URL.Query().Get("excludeDeleted"))
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, ListResponse)
+	})
+
+	opts := projects.ListOpts{CheckCOValidity: true, ExcludeDeleted: true}
+	report, err := completeness.Generate(t.Context(), client.ServiceClient(fakeServer), opts)
+	th.AssertNoErr(t, err)
+
+	// complete projects are skipped, the rest is sorted by domain name
+	th.AssertEquals(t, 2, len(report.Entries))
+	th.AssertEquals(t, "p3", report.Entries[0].ProjectID)
+	th.AssertEquals(t, "p2", report.Entries[1].ProjectID)
+
+	byDomain := report.ByDomain()
+	th.AssertEquals(t, 2, len(byDomain))
+	th.AssertEquals(t, "d0", byDomain[0].Key)
+	th.AssertEquals(t, "d1", byDomain[1].Key)
+
+	byContact := report.ByContact()
+	keys := make([]string, 0, len(byContact))
+	for _, g := range byContact {
+		keys = append(keys, g.Key)
+	}
+	th.CheckDeepEquals(t, []string{"", "alice@mail.com", "ops@mail.com"}, keys)
+
+	var buf bytes.Buffer
+	th.AssertNoErr(t, report.WriteCSV(&buf))
+	th.AssertEquals(t, ExpectedCSV, buf.String())
+
+	buf.Reset()
+	th.AssertNoErr(t, report.WriteJSON(&buf))
+	var decoded completeness.Report
+	th.AssertNoErr(t, json.Unmarshal(buf.Bytes(), &decoded))
+	th.CheckDeepEquals(t, report, decoded)
+}
@@ -0,0 +1,201 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+// Package costobjects resolves the effective cost object of projects by
+// combining project and domain masterdata.
+//
+// A project either has its own cost object, or inherits the cost object of
+// its domain. Inheritance is only possible if the domain's cost object has
+// ProjectsCanInherit set.
+//
+// The Validator checks cost objects against the cost objects known to Metis
+// before they are written into billing masterdata.
+package costobjects
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/gophercloud/gophercloud/v2"
+
+	"github.com/sapcc/gophercloud-sapcc/v2/billing/masterdata/domains"
+	"github.com/sapcc/gophercloud-sapcc/v2/billing/masterdata/projects"
+)
+
+// Source describes where an effective cost object comes from.
+type Source string
+
+const (
+	// SourceProject means that the project has its own cost object.
+	SourceProject Source = "project"
+	// SourceDomain means that the project inherits the cost object of its
+	// domain.
+	SourceDomain Source = "domain"
+)
+
+// EffectiveCostObject is the cost object that is charged for a project.
+type EffectiveCostObject struct {
+	ProjectID   string
+	ProjectName string
+	DomainID    string
+	DomainName  string
+	// Name and Type of the effective cost object.
+	Name string
+	Type string
+	// Source is the provenance of the cost object.
+	Source Source
+}
+
+// UnresolvableError is returned when the effective cost object of a project
+// cannot be determined from the masterdata.
+type UnresolvableError struct {
+	ProjectID string
+	DomainID  string
+	Reason    string
+}
+
+// Error implements the builtin/error interface.
+func (e UnresolvableError) Error() string {
+	return fmt.Sprintf("cannot resolve cost object of project %s in domain %s: %s", e.ProjectID, e.DomainID, e.Reason)
+}
+
+// Resolver resolves effective cost objects. Domain masterdata is cached for
+// the lifetime of the Resolver, so a Resolver should be short-lived, e.g. one
+// per report. It is safe for concurrent use.
+type Resolver struct {
+	client *gophercloud.ServiceClient
+
+	mutex   sync.Mutex
+	domains map[string]domains.Domain
+}
+
+// NewResolver returns a Resolver that reads masterdata with the given billing
+// client.
+func NewResolver(client *gophercloud.ServiceClient) *Resolver {
+	return &Resolver{
+		client:  client,
+		domains: make(map[string]domains.Domain),
+	}
+}
+
+// Resolve returns the effective cost object of the project with the given ID.
+func (r *Resolver) Resolve(ctx context.Context, projectID string) (EffectiveCostObject, error) {
+	project, err := projects.Get(ctx, r.client, projectID).Extract()
+	if err != nil {
+		return EffectiveCostObject{}, err
+	}
+	return r.ResolveProject(ctx, *project)
+}
+
+// ResolveProject returns the effective cost object of the given project. The
+// domain is only read if the project inherits its cost object.
+func (r *Resolver) ResolveProject(ctx context.Context, project projects.Project) (EffectiveCostObject, error) {
+	result := EffectiveCostObject{
+		ProjectID:   project.ProjectID,
+		ProjectName: project.ProjectName,
+		DomainID:    project.DomainID,
+		DomainName:  project.DomainName,
+	}
+	unresolvable := func(reason string) error {
+		return UnresolvableError{ProjectID: project.ProjectID, DomainID: project.DomainID, Reason: reason}
+	}
+
+	if !project.CostObject.Inherited {
+		if project.CostObject.Name == "" || project.CostObject.Type == "" {
+			return result, unresolvable("project has neither its own nor an inherited cost object")
+		}
+		result.Name = project.CostObject.Name
+		result.Type = project.CostObject.Type
+		result.Source = SourceProject
+		return result, nil
+	}
+
+	domain, err := r.domain(ctx, project.DomainID)
+	if err != nil {
+		return result, err
+	}
+	if !domain.CostObject.ProjectsCanInherit {
+		return result, unresolvable("project inherits its cost object, but the domain does not allow inheritance")
+	}
+	if domain.CostObject.Name == "" || domain.CostObject.Type == "" {
+		return result, unresolvable("project inherits its cost object, but the domain has none")
+	}
+	result.Name = domain.CostObject.Name
+	result.Type = domain.CostObject.Type
+	result.Source = SourceDomain
+	return result, nil
+}
+
+// ResolveAllResult is an element of the result of ResolveAll.
+type ResolveAllResult struct {
+	EffectiveCostObject
+	// Err is set if the cost object of this project could not be resolved.
+	Err error
+}
+
+// ResolveAll resolves the effective cost objects of all projects returned by
+// projects.List with the given options. All domains are loaded with a single
+// domains.List call. Errors for individual projects are reported in the
+// respective result; the returned error is only set if listing fails.
+func (r *Resolver) ResolveAll(ctx context.Context, opts projects.ListOpts) ([]ResolveAllResult, error) {
+	err := r.loadDomains(ctx, domains.ListOpts{ExcludeDeleted: opts.ExcludeDeleted})
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := projects.List(r.client, opts).AllPages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	allProjects, err := projects.ExtractProjects(page)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]ResolveAllResult, 0, len(allProjects))
+	for _, project := range allProjects {
+		eco, resolveErr := r.ResolveProject(ctx, project)
+		result = append(result, ResolveAllResult{EffectiveCostObject: eco, Err: resolveErr})
+	}
+	return result, nil
+}
+
+// domain returns the domain with the given ID from the cache, or reads it.
+func (r *Resolver) domain(ctx context.Context, domainID string) (domains.Domain, error) {
+	r.mutex.Lock()
+	domain, exists := r.domains[domainID]
+	r.mutex.Unlock()
+	if exists {
+		return domain, nil
+	}
+
+	d, err := domains.Get(ctx, r.client, domainID).Extract()
+	if err != nil {
+		return domains.Domain{}, err
+	}
+
+	r.mutex.Lock()
+	r.domains[domainID] = *d
+	r.mutex.Unlock()
+	return *d, nil
+}
+
+// loadDomains fills the cache with all domains.
+func (r *Resolver) loadDomains(ctx context.Context, opts domains.ListOpts) error {
+	page, err := domains.List(r.client, opts).AllPages(ctx)
+	if err != nil {
+		return err
+	}
+	allDomains, err := domains.ExtractDomains(page)
+	if err != nil {
+		return err
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, d := range allDomains {
+		r.domains[d.DomainID] = d
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package testing
+
+const ProjectListResponse = `
+[
+  {
+    "project_id": "p-own",
+    "project_name": "own",
+    "domain_id": "d-inheritable",
+    "domain_name": "inheritable",
+    "cost_object": {"name": "1111", "type": "CC", "inherited": false}
+  },
+  {
+    "project_id": "p-inherited",
+    "project_name": "inherited",
+    "domain_id": "d-inheritable",
+    "domain_name": "inheritable",
+    "cost_object": {"inherited": true}
+  },
+  {
+    "project_id": "p-forbidden",
+    "project_name": "forbidden",
+    "domain_id": "d-closed",
+    "domain_name": "closed",
+    "cost_object": {"inherited": true}
+  }
+]
+`
+
+const ProjectGetResponse = `
+{
+  "project_id": "p-inherited",
+  "project_name": "inherited",
+  "domain_id": "d-inheritable",
+  "domain_name": "inheritable",
+  "cost_object": {"inherited": true}
+}
+`
+
+const DomainListResponse = `
+[
+  {
+    "domain_id": "d-inheritable",
+    "domain_name": "inheritable",
+    "cost_object": {"name": "2222", "type": "IO", "projects_can_inherit": true}
+  },
+  {
+    "domain_id": "d-closed",
+    "domain_name": "closed",
+    "cost_object": {"name": "3333", "type": "WBS", "projects_can_inherit": false}
+  }
+]
+`
+
+const DomainGetResponse = `
+{
+  "domain_id": "d-inheritable",
+  "domain_name": "inheritable",
+  "cost_object": {"name": "2222", "type": "IO", "projects_can_inherit": true}
+}
+`
+
+const DomainClosedGetResponse = `
+{
+  "domain_id": "d-closed",
+  "domain_name": "closed",
+  "cost_object": {"name": "3333", "type": "WBS", "projects_can_inherit": false}
+}
+`
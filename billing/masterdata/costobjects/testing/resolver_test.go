@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package testing
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+	"github.com/gophercloud/gophercloud/v2/testhelper/client"
+
+	"github.com/sapcc/gophercloud-sapcc/v2/billing/masterdata/costobjects"
+	"github.com/sapcc/gophercloud-sapcc/v2/billing/masterdata/projects"
+)
+
+func TestResolve(t *testing.T) {
+	fakeServer := th.SetupHTTP()
+	defer fakeServer.Teardown()
+
+	fakeServer.Mux.HandleFunc("/masterdata/projects/p-inherited", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, http.MethodGet)
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, ProjectGetResponse)
+	})
+	domainGets := 0
+	fakeServer.Mux.HandleFunc("/masterdata/domains/d-inheritable", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, http.MethodGet)
+		domainGets++
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, DomainGetResponse)
+	})
+
+	resolver := costobjects.NewResolver(client.ServiceClient(fakeServer))
+	for range 2 {
+		actual, err := resolver.Resolve(t.Context(), "p-inherited")
+		th.AssertNoErr(t, err)
+		th.CheckDeepEquals(t, costobjects.EffectiveCostObject{
+			ProjectID:   "p-inherited",
+			ProjectName: "inherited",
+			DomainID:    "d-inheritable",
+			DomainName:  "inheritable",
+			Name:        "2222",
+			Type:        "IO",
+			Source:      costobjects.SourceDomain,
+		}, actual)
+	}
+	// the domain is cached
+	th.AssertEquals(t, 1, domainGets)
+}
+
+func TestResolveAll(t *testing.T) {
+	fakeServer := th.SetupHTTP()
+	defer fakeServer.Teardown()
+
+	fakeServer.Mux.HandleFunc("/masterdata/projects", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, http.MethodGet)
+		th.CheckEquals(t, "true", r.URL.Query().Get("excludeDeleted"))
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, ProjectListResponse)
+	})
+	fakeServer.Mux.HandleFunc("/masterdata/domains", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, http.MethodGet)
+		th.CheckEquals(t, "true", r.URL.Query().Get("excludeDeleted"))
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, DomainListResponse)
+	})
+
+	resolver := costobjects.NewResolver(client.ServiceClient(fakeServer))
+	results, err := resolver.ResolveAll(t.Context(), projects.ListOpts{ExcludeDeleted: true})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 3, len(results))
+
+	th.AssertNoErr(t, results[0].Err)
+	th.AssertEquals(t, "1111", results[0].Name)
+	th.AssertEquals(t, "CC", results[0].Type)
+	th.AssertEquals(t, costobjects.SourceProject, results[0].Source)
+
+	th.AssertNoErr(t, results[1].Err)
+	th.AssertEquals(t, "2222", results[1].Name)
+	th.AssertEquals(t, costobjects.SourceDomain, results[1].Source)
+
+	var uerr costobjects.UnresolvableError
+	th.AssertEquals(t, true, errors.As(results[2].Err, &uerr))
+	th.AssertEquals(t, "p-forbidden", uerr.ProjectID)
+	th.AssertEquals(t, "", results[2].Name)
+}
@@ -0,0 +1,170 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package testing
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+	"github.com/gophercloud/gophercloud/v2/testhelper/client"
+
+	"github.com/sapcc/gophercloud-sapcc/v2/billing/masterdata/costobjects"
+	"github.com/sapcc/gophercloud-sapcc/v2/billing/masterdata/domains"
+	"github.com/sapcc/gophercloud-sapcc/v2/billing/masterdata/projects"
+)
+
+// handleMetisCostObjects serves /identity/costobject. 1111 and 2222 exist,
+// but only 2222 is assigned to a domain.
+func handleMetisCostObjects(t *testing.T, fakeServer th.FakeServer) {
+	fakeServer.Mux.HandleFunc("/identity/costobject", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, http.MethodGet)
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		q := r.URL.Query()
+		known := map[string]string{"1111": "CC", "2222": "IO"}
+		var items []map[string]string
+		for _, name := range q["uuids"] {
+			coType, exists := known[name]
+			assigned := !q.Has("project") && (!q.Has("domain") || (name == "2222" && q.Get("domain") == "d-inheritable"))
+			if exists && assigned {
+				items = append(items, map[string]string{"name": name, "type": coType})
+			}
+		}
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		err := json.NewEncoder(w).Encode(map[string]any{
+			"apiVersion": "1.0",
+			"data":       map[string]any{"kind": "costobject", "items": items},
+		})
+		th.AssertNoErr(t, err)
+	})
+}
+
+func handleMasterdata(t *testing.T, fakeServer th.FakeServer) {
+	responses := map[string]string{
+		"/masterdata/projects/p-inherited":  ProjectGetResponse,
+		"/masterdata/domains/d-inheritable": DomainGetResponse,
+		"/masterdata/domains/d-closed":      DomainClosedGetResponse,
+	}
+	for path, body := range responses {
+		fakeServer.Mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			th.TestMethod(t, r, http.MethodGet)
+
+			w.Header().Add("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, body)
+		})
+	}
+}
+
+func TestValidateProject(t *testing.T) {
+	fakeServer := th.SetupHTTP()
+	defer fakeServer.Teardown()
+	handleMetisCostObjects(t, fakeServer)
+	handleMasterdata(t, fakeServer)
+
+	sc := client.ServiceClient(fakeServer)
+	v := costobjects.NewValidator(sc, sc, costobjects.ValidatorOpts{})
+	own := func(name string, coType string) projects.UpdateOpts {
+		return projects.UpdateOpts{CostObject: projects.CostObject{Name: name, Type: coType}}
+	}
+
+	th.AssertNoErr(t, v.ValidateProject(t.Context(), "p-own", own("1111", "CC")))
+
+	var ierr costobjects.InvalidCostObjectError
+	err := v.ValidateProject(t.Context(), "p-own", own("1111", "IO"))
+	th.AssertEquals(t, true, errors.As(err, &ierr))
+	th.AssertEquals(t, "invalid cost object 1111 (IO) for project p-own: Metis reports type CC", err.Error())
+
+	err = v.ValidateProject(t.Context(), "p-own", own("9999", "CC"))
+	th.AssertEquals(t, "invalid cost object 9999 (CC) for project p-own: not found in Metis", err.Error())
+
+	// the domain is read from the existing project
+	inherited := projects.UpdateOpts{CostObject: projects.CostObject{Inherited: true}}
+	th.AssertNoErr(t, v.ValidateProject(t.Context(), "p-inherited", inherited))
+
+	inherited.DomainID = "d-closed"
+	err = v.ValidateProject(t.Context(), "p-forbidden", inherited)
+	var uerr costobjects.UnresolvableError
+	th.AssertEquals(t, true, errors.As(err, &uerr))
+
+	// with CheckAssignment, only 2222 is valid for projects in d-inheritable
+	v = costobjects.NewValidator(sc, sc, costobjects.ValidatorOpts{CheckAssignment: true})
+	err = v.ValidateProject(t.Context(), "p-own", own("1111", "CC"))
+	th.AssertEquals(t, "invalid cost object 1111 (CC) for project p-own: not assigned to project p-own in Metis", err.Error())
+	inherited.DomainID = "d-inheritable"
+	th.AssertNoErr(t, v.ValidateProject(t.Context(), "p-inherited", inherited))
+}
+
+func TestValidateDomain(t *testing.T) {
+	fakeServer := th.SetupHTTP()
+	defer fakeServer.Teardown()
+	handleMetisCostObjects(t, fakeServer)
+
+	sc := client.ServiceClient(fakeServer)
+	v := costobjects.NewValidator(sc, sc, costobjects.ValidatorOpts{CheckAssignment: true})
+
+	th.AssertNoErr(t, v.ValidateDomain(t.Context(), "d-inheritable", domains.UpdateOpts{
+		CostObject: domains.CostObject{Name: "2222", Type: "IO", ProjectsCanInherit: true},
+	}))
+	th.AssertNoErr(t, v.ValidateDomain(t.Context(), "d-empty", domains.UpdateOpts{}))
+
+	err := v.ValidateDomain(t.Context(), "d-empty", domains.UpdateOpts{
+		CostObject: domains.CostObject{ProjectsCanInherit: true},
+	})
+	th.AssertEquals(t, "invalid cost object for domain d-empty: projects can inherit the cost object, but the domain has none", err.Error())
+
+	err = v.ValidateDomain(t.Context(), "d-closed", domains.UpdateOpts{
+		CostObject: domains.CostObject{Name: "2222", Type: "IO"},
+	})
+	th.AssertEquals(t, "invalid cost object 2222 (IO) for domain d-closed: not assigned to domain d-closed in Metis", err.Error())
+}
+
+func TestValidateAndUpdate(t *testing.T) {
+	fakeServer := th.SetupHTTP()
+	defer fakeServer.Teardown()
+	handleMetisCostObjects(t, fakeServer)
+	fakeServer.Mux.HandleFunc("/masterdata/projects/p-own", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, http.MethodPut)
+		var body struct {
+			CostObject projects.CostObject `json:"cost_object"`
+		}
+		th.AssertNoErr(t, json.NewDecoder(r.Body).Decode(&body))
+		th.AssertEquals(t, "1111", body.CostObject.Name)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"project_id": "p-own", "cost_object": {"name": "1111", "type": "CC", "inherited": false}}`)
+	})
+	fakeServer.Mux.HandleFunc("/masterdata/domains/d-closed", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected %s request", r.Method)
+	})
+
+	sc := client.ServiceClient(fakeServer)
+	v := costobjects.NewValidator(sc, sc, costobjects.ValidatorOpts{})
+
+	// an invalid cost object is not sent to billing
+	opts := projects.UpdateOpts{CostObject: projects.CostObject{Name: "9999", Type: "CC"}}
+	_, err := v.ValidateAndUpdateProject(t.Context(), "p-own", opts).Extract()
+	var ierr costobjects.InvalidCostObjectError
+	th.AssertEquals(t, true, errors.As(err, &ierr))
+	th.AssertEquals(t, "9999", ierr.Name)
+
+	_, err = v.ValidateAndUpdateDomain(t.Context(), "d-closed", domains.UpdateOpts{
+		CostObject: domains.CostObject{ProjectsCanInherit: true},
+	}).Extract()
+	th.AssertEquals(t, true, errors.As(err, &ierr))
+	th.AssertEquals(t, "d-closed", ierr.DomainID)
+
+	// a valid cost object is sent
+	opts.CostObject.Name = "1111"
+	p, err := v.ValidateAndUpdateProject(t.Context(), "p-own", opts).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "1111", p.CostObject.Name)
+}
@@ -0,0 +1,188 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package costobjects
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gophercloud/gophercloud/v2"
+
+	"github.com/sapcc/gophercloud-sapcc/v2/billing/masterdata/domains"
+	"github.com/sapcc/gophercloud-sapcc/v2/billing/masterdata/projects"
+	metiscostobjects "github.com/sapcc/gophercloud-sapcc/v2/metis/v1/identity/costobjects"
+)
+
+// ValidatorOpts configures a Validator.
+type ValidatorOpts struct {
+	// CheckAssignment additionally requires that Metis lists the cost object
+	// for the project or domain that it is written to. Inherited cost objects
+	// are checked against the domain.
+	CheckAssignment bool
+}
+
+// InvalidCostObjectError is returned by a Validator when a cost object does
+// not exist in Metis or does not match the Metis cost object.
+type InvalidCostObjectError struct {
+	// Either ProjectID or DomainID is set, depending on the validated object.
+	ProjectID string
+	DomainID  string
+	Name      string
+	Type      string
+	Reason    string
+}
+
+// Error implements the builtin/error interface.
+func (e InvalidCostObjectError) Error() string {
+	target := "domain " + e.DomainID
+	if e.ProjectID != "" {
+		target = "project " + e.ProjectID
+	}
+	if e.Name == "" {
+		return fmt.Sprintf("invalid cost object for %s: %s", target, e.Reason)
+	}
+	return fmt.Sprintf("invalid cost object %s (%s) for %s: %s", e.Name, e.Type, target, e.Reason)
+}
+
+// Validator checks cost objects before they are written into billing
+// masterdata. Names and types are looked up in Metis with
+// metis/v1/identity/costobjects.List. For projects that inherit their cost
+// object, the inheritance rules are checked with a Resolver and the domain's
+// cost object is looked up instead.
+//
+// Like a Resolver, a Validator caches domain masterdata and should be
+// short-lived. It is safe for concurrent use.
+type Validator struct {
+	metisClient *gophercloud.ServiceClient
+	resolver    *Resolver
+	opts        ValidatorOpts
+}
+
+// NewValidator returns a Validator that reads masterdata with the given
+// billing client and cost objects with the given Metis client.
+func NewValidator(billingClient, metisClient *gophercloud.ServiceClient, opts ValidatorOpts) *Validator {
+	return &Validator{
+		metisClient: metisClient,
+		resolver:    NewResolver(billingClient),
+		opts:        opts,
+	}
+}
+
+// ValidateProject checks the cost object of the given project UpdateOpts. If
+// the cost object is inherited, the domain is taken from opts.DomainID, or
+// read from the existing project masterdata if opts.DomainID is empty.
+func (v *Validator) ValidateProject(ctx context.Context, projectID string, opts projects.UpdateOpts) error {
+	project := projects.Project{
+		ProjectID:  projectID,
+		DomainID:   opts.DomainID,
+		CostObject: opts.CostObject,
+	}
+	if project.CostObject.Inherited && project.DomainID == "" {
+		existing, err := projects.Get(ctx, v.resolver.client, projectID).Extract()
+		if err != nil {
+			return err
+		}
+		project.DomainID = existing.DomainID
+	}
+
+	eco, err := v.resolver.ResolveProject(ctx, project)
+	if err != nil {
+		return err
+	}
+	scope := metiscostobjects.ListOpts{Project: projectID}
+	if eco.Source == SourceDomain {
+		scope = metiscostobjects.ListOpts{Domain: eco.DomainID}
+	}
+	reason, err := v.lookup(ctx, eco.Name, eco.Type, scope)
+	if err != nil || reason == "" {
+		return err
+	}
+	if eco.Source == SourceDomain {
+		reason = "inherited from domain " + eco.DomainID + ": " + reason
+	}
+	return InvalidCostObjectError{ProjectID: projectID, Name: eco.Name, Type: eco.Type, Reason: reason}
+}
+
+// ValidateDomain checks the cost object of the given domain UpdateOpts. A
+// domain may have no cost object, unless ProjectsCanInherit is set.
+func (v *Validator) ValidateDomain(ctx context.Context, domainID string, opts domains.UpdateOpts) error {
+	co := opts.CostObject
+	if co.Name == "" && co.Type == "" {
+		if co.ProjectsCanInherit {
+			return InvalidCostObjectError{DomainID: domainID, Reason: "projects can inherit the cost object, but the domain has none"}
+		}
+		return nil
+	}
+
+	reason, err := v.lookup(ctx, co.Name, co.Type, metiscostobjects.ListOpts{Domain: domainID})
+	if err != nil || reason == "" {
+		return err
+	}
+	return InvalidCostObjectError{DomainID: domainID, Name: co.Name, Type: co.Type, Reason: reason}
+}
+
+// ValidateAndUpdateProject checks the cost object with ValidateProject and
+// then updates the project with projects.Update, using the billing client of
+// the Validator. If the check fails, no update request is sent and the error
+// is returned in the UpdateResult.
+func (v *Validator) ValidateAndUpdateProject(ctx context.Context, projectID string, opts projects.UpdateOpts) (r projects.UpdateResult) {
+	err := v.ValidateProject(ctx, projectID, opts)
+	if err != nil {
+		r.Err = err
+		return
+	}
+	return projects.Update(ctx, v.resolver.client, projectID, opts)
+}
+
+// ValidateAndUpdateDomain checks the cost object with ValidateDomain and then
+// updates the domain with domains.Update, using the billing client of the
+// Validator. If the check fails, no update request is sent and the error is
+// returned in the UpdateResult.
+func (v *Validator) ValidateAndUpdateDomain(ctx context.Context, domainID string, opts domains.UpdateOpts) (r domains.UpdateResult) {
+	err := v.ValidateDomain(ctx, domainID, opts)
+	if err != nil {
+		r.Err = err
+		return
+	}
+	return domains.Update(ctx, v.resolver.client, domainID, opts)
+}
+
+// lookup finds the cost object with the given name in Metis. It returns the
+// reason why the cost object is invalid, or an empty string if it is valid.
+// The Project and Domain filters of scope are only used if
+// ValidatorOpts.CheckAssignment is set.
+func (v *Validator) lookup(ctx context.Context, name, coType string, scope metiscostobjects.ListOpts) (string, error) {
+	opts := metiscostobjects.ListOpts{UUIDs: []string{name}}
+	if v.opts.CheckAssignment {
+		opts.Project = scope.Project
+		opts.Domain = scope.Domain
+	}
+	page, err := metiscostobjects.List(v.metisClient, opts).AllPages(ctx)
+	if err != nil {
+		return "", err
+	}
+	found, err := metiscostobjects.Extract(page)
+	if err != nil {
+		return "", err
+	}
+
+	for _, co := range found {
+		if co.Name != name {
+			continue
+		}
+		if !strings.EqualFold(co.Type, coType) {
+			return fmt.Sprintf("Metis reports type %s", co.Type), nil
+		}
+		return "", nil
+	}
+	switch {
+	case !v.opts.CheckAssignment:
+		return "not found in Metis", nil
+	case scope.Project != "":
+		return "not assigned to project " + scope.Project + " in Metis", nil
+	default:
+		return "not assigned to domain " + scope.Domain + " in Metis", nil
+	}
+}
@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package domains
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2"
+
+	"github.com/sapcc/gophercloud-sapcc/v2/billing/masterdata/internal/patch"
+)
+
+// PatchAttempts is the number of times Patch tries to apply a change before
+// it gives up with a ConflictError.
+const PatchAttempts = patch.Attempts
+
+// ConflictError is returned by Patch when the domain was modified
+// concurrently in each attempt.
+type ConflictError = patch.ConflictError
+
+// readOnlyFields are fields of a domain that are only contained in server
+// responses and must not be sent back in an update.
+var readOnlyFields = []string{
+	"iid", "created_at", "changed_at", "changed_by",
+	"is_complete", "missing_attributes",
+}
+
+// Patch updates a domain with a read-modify-write cycle: it reads the
+// domain, converts it with DomainToUpdateOpts, calls mutate on the result
+// and writes it back. The request body is built from the mutated UpdateOpts,
+// so fields that mutate clears are cleared on the server. Fields of the
+// original response body that are not known to UpdateOpts are sent back
+// unchanged, so that they are not dropped.
+//
+// Right before writing, the domain is read again. If ChangedAt or ChangedBy
+// differ from the first read, or the server reports a conflict, the cycle
+// is restarted with the current domain and mutate is called again. After
+// PatchAttempts unsuccessful cycles, the result contains a ConflictError.
+func Patch(ctx context.Context, c *gophercloud.ServiceClient, id string, mutate func(*UpdateOpts)) (r UpdateResult) {
+	r.Result = patch.Run(ctx, id, patch.Cycle[Domain, UpdateOpts]{
+		Kind: "domain",
+		Get: func(ctx context.Context) (*Domain, any, error) {
+			res := Get(ctx, c, id)
+			domain, err := res.Extract()
+			return domain, res.Body, err
+		},
+		Version: func(domain *Domain) (time.Time, string) {
+			return domain.ChangedAt, domain.ChangedBy
+		},
+		ToOpts: DomainToUpdateOpts,
+		ToMap:  UpdateOpts.ToDomainUpdateMap,
+		Put: func(ctx context.Context, body map[string]any, r *gophercloud.Result) {
+			//nolint:bodyclose // already handled by gophercloud
+			resp, err := c.Put(ctx, updateURL(c, id), body, &r.Body, &gophercloud.RequestOpts{
+				OkCodes: []int{http.StatusOK},
+			})
+			_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+		},
+		ReadOnly: readOnlyFields,
+	}, mutate)
+	return r
+}
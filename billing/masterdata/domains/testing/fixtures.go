@@ -87,3 +87,40 @@ const UpdateResponse = `
   "missing_attributes": null
 }
 `
+
+const PatchRequest = `
+{
+  "domain_id": "707c94677ac741ecb1f2cabc804c1285",
+  "domain_name": "master",
+  "description": "new example domain",
+  "cost_object": {
+    "name": "1234567",
+    "type": "IO",
+    "projects_can_inherit": false
+  },
+  "responsible_primary_contact_id": "",
+  "responsible_primary_contact_email": "",
+  "additional_information": "",
+  "collector": "billing.region.local",
+  "region": "region",
+  "future_field": {"nested": true}
+}
+`
+
+// PatchClearRequest is the PUT body after the cost object and the domain
+// name were cleared.
+const PatchClearRequest = `
+{
+  "domain_id": "707c94677ac741ecb1f2cabc804c1285",
+  "description": "example domain",
+  "cost_object": {
+    "projects_can_inherit": true
+  },
+  "responsible_primary_contact_id": "",
+  "responsible_primary_contact_email": "",
+  "additional_information": "",
+  "collector": "billing.region.local",
+  "region": "region",
+  "future_field": {"nested": true}
+}
+`
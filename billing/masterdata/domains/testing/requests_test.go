@@ -6,6 +6,7 @@ package testing
 import (
 	"fmt"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
@@ -162,3 +163,57 @@ func TestUpdate(t *testing.T) {
 
 	th.AssertDeepEquals(t, *s, updateResponse)
 }
+
+func TestPatch(t *testing.T) {
+	fakeServer := th.SetupHTTP()
+	defer fakeServer.Teardown()
+
+	fakeServer.Mux.HandleFunc("/masterdata/domains/707c94677ac741ecb1f2cabc804c1285", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		w.Header().Add("Content-Type", "application/json")
+
+		switch r.Method {
+		case http.MethodGet:
+			// inject a field that is unknown to UpdateOpts
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, strings.Replace(GetResponse, `"iid": 123,`, `"iid": 123, "future_field": {"nested": true},`, 1))
+		case http.MethodPut:
+			th.TestJSONRequest(t, r, PatchRequest)
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, UpdateResponse)
+		}
+	})
+
+	s, err := domains.Patch(t.Context(), client.ServiceClient(fakeServer), "707c94677ac741ecb1f2cabc804c1285", func(opts *domains.UpdateOpts) {
+		opts.Description = "new example domain"
+	}).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertDeepEquals(t, updateResponse, *s)
+}
+
+func TestPatchClearsFields(t *testing.T) {
+	fakeServer := th.SetupHTTP()
+	defer fakeServer.Teardown()
+
+	fakeServer.Mux.HandleFunc("/masterdata/domains/707c94677ac741ecb1f2cabc804c1285", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Content-Type", "application/json")
+
+		switch r.Method {
+		case http.MethodGet:
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, strings.Replace(GetResponse, `"iid": 123,`, `"iid": 123, "future_field": {"nested": true},`, 1))
+		case http.MethodPut:
+			th.TestJSONRequest(t, r, PatchClearRequest)
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, UpdateResponse)
+		}
+	})
+
+	_, err := domains.Patch(t.Context(), client.ServiceClient(fakeServer), "707c94677ac741ecb1f2cabc804c1285", func(opts *domains.UpdateOpts) {
+		opts.DomainName = ""
+		opts.CostObject.Name = ""
+		opts.CostObject.Type = ""
+		opts.CostObject.ProjectsCanInherit = true
+	}).Extract()
+	th.AssertNoErr(t, err)
+}
@@ -0,0 +1,330 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+// Package drift compares the billing masterdata of projects with the CBR
+// masterdata that Metis exposes for the same projects, in order to find
+// synchronization bugs between both systems.
+package drift
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gophercloud/gophercloud/v2"
+
+	"github.com/sapcc/gophercloud-sapcc/v2/billing/masterdata/projects"
+	metisprojects "github.com/sapcc/gophercloud-sapcc/v2/metis/v1/identity/projects"
+)
+
+// MetisBatchSize is the maximum number of project UUIDs that Detect requests
+// from Metis with a single List call.
+const MetisBatchSize = 100
+
+// Field maps a field of the billing masterdata to the corresponding field of
+// the Metis CBR masterdata.
+type Field struct {
+	// Billing is the JSON name of the field in billing masterdata, e.g.
+	// "business_criticality". Nested fields are separated by dots.
+	Billing string
+	// Metis is the JSON name of the field in Metis, e.g.
+	// "cbrMasterdata.businessCriticality".
+	Metis string
+
+	billingValue func(projects.Project) string
+	metisValue   func(metisprojects.Project) string
+	equal        func(string, string) bool
+	// skip reports whether the field is not comparable for a project.
+	skip func(projects.Project) bool
+}
+
+// Fields returns the mapping of all fields that are compared by Compare.
+func Fields() []Field {
+	return slices.Clone(fields)
+}
+
+var fields = []Field{
+	{
+		Billing:      "project_name",
+		Metis:        "name",
+		billingValue: func(p projects.Project) string { return p.ProjectName },
+		metisValue:   func(m metisprojects.Project) string { return m.Name },
+	},
+	{
+		Billing:      "domain_id",
+		Metis:        "domainUUID",
+		billingValue: func(p projects.Project) string { return p.DomainID },
+		metisValue:   func(m metisprojects.Project) string { return m.DomainUUID },
+	},
+	{
+		Billing:      "cost_object.inherited",
+		Metis:        "cbrMasterdata.costObjectInherited",
+		billingValue: func(p projects.Project) string { return strconv.FormatBool(p.CostObject.Inherited) },
+		metisValue: func(m metisprojects.Project) string {
+			return strconv.FormatBool(m.CBRMasterdata.CostObjectInherited)
+		},
+	},
+	{
+		Billing:      "cost_object.name",
+		Metis:        "cbrMasterdata.costObjectName",
+		billingValue: func(p projects.Project) string { return p.CostObject.Name },
+		metisValue:   func(m metisprojects.Project) string { return m.CBRMasterdata.CostObjectName },
+		skip:         costObjectInherited,
+	},
+	{
+		Billing:      "cost_object.type",
+		Metis:        "cbrMasterdata.costObjectType",
+		billingValue: func(p projects.Project) string { return p.CostObject.Type },
+		metisValue:   func(m metisprojects.Project) string { return m.CBRMasterdata.CostObjectType },
+		equal:        strings.EqualFold,
+		skip:         costObjectInherited,
+	},
+	{
+		Billing:      "business_criticality",
+		Metis:        "cbrMasterdata.businessCriticality",
+		billingValue: func(p projects.Project) string { return p.BusinessCriticality },
+		metisValue:   func(m metisprojects.Project) string { return m.CBRMasterdata.BusinessCriticality },
+		equal:        strings.EqualFold,
+	},
+	{
+		Billing:      "revenue_relevance",
+		Metis:        "cbrMasterdata.revenueRelevance",
+		billingValue: func(p projects.Project) string { return p.RevenueRelevance },
+		metisValue:   func(m metisprojects.Project) string { return m.CBRMasterdata.RevenueRelevance },
+		equal:        strings.EqualFold,
+	},
+	{
+		Billing:      "number_of_endusers",
+		Metis:        "cbrMasterdata.numberOfEndusers",
+		billingValue: func(p projects.Project) string { return strconv.Itoa(p.NumberOfEndusers) },
+		metisValue:   func(m metisprojects.Project) string { return strconv.Itoa(m.CBRMasterdata.NumberOfEndusers) },
+	},
+	{
+		Billing:      "responsible_primary_contact_id",
+		Metis:        "cbrMasterdata.primaryContactUserID",
+		billingValue: func(p projects.Project) string { return p.ResponsiblePrimaryContactID },
+		metisValue:   func(m metisprojects.Project) string { return m.CBRMasterdata.PrimaryContactUserID },
+		equal:        strings.EqualFold,
+	},
+	{
+		Billing:      "responsible_primary_contact_email",
+		Metis:        "cbrMasterdata.primaryContactEmail",
+		billingValue: func(p projects.Project) string { return p.ResponsiblePrimaryContactEmail },
+		metisValue:   func(m metisprojects.Project) string { return m.CBRMasterdata.PrimaryContactEmail },
+		equal:        strings.EqualFold,
+	},
+	{
+		Billing:      "responsible_operator_id",
+		Metis:        "cbrMasterdata.operatorUserID",
+		billingValue: func(p projects.Project) string { return p.ResponsibleOperatorID },
+		metisValue:   func(m metisprojects.Project) string { return m.CBRMasterdata.OperatorUserID },
+		equal:        strings.EqualFold,
+	},
+	{
+		Billing:      "responsible_operator_email",
+		Metis:        "cbrMasterdata.operatorEmail",
+		billingValue: func(p projects.Project) string { return p.ResponsibleOperatorEmail },
+		metisValue:   func(m metisprojects.Project) string { return m.CBRMasterdata.OperatorEmail },
+		equal:        strings.EqualFold,
+	},
+	{
+		Billing:      "responsible_inventory_role_id",
+		Metis:        "cbrMasterdata.inventoryRoleUserID",
+		billingValue: func(p projects.Project) string { return p.ResponsibleInventoryRoleID },
+		metisValue:   func(m metisprojects.Project) string { return m.CBRMasterdata.InventoryRoleUserID },
+		equal:        strings.EqualFold,
+	},
+	{
+		Billing:      "responsible_inventory_role_email",
+		Metis:        "cbrMasterdata.inventoryRoleEmail",
+		billingValue: func(p projects.Project) string { return p.ResponsibleInventoryRoleEmail },
+		metisValue:   func(m metisprojects.Project) string { return m.CBRMasterdata.InventoryRoleEmail },
+		equal:        strings.EqualFold,
+	},
+	{
+		Billing:      "responsible_infrastructure_coordinator_id",
+		Metis:        "cbrMasterdata.infrastructureCoordinatorUserID",
+		billingValue: func(p projects.Project) string { return p.ResponsibleInfrastructureCoordinatorID },
+		metisValue: func(m metisprojects.Project) string {
+			return m.CBRMasterdata.InfrastructureCoordinatorUserID
+		},
+		equal: strings.EqualFold,
+	},
+	{
+		Billing:      "responsible_infrastructure_coordinator_email",
+		Metis:        "cbrMasterdata.infrastructureCoordinatorEmail",
+		billingValue: func(p projects.Project) string { return p.ResponsibleInfrastructureCoordinatorEmail },
+		metisValue: func(m metisprojects.Project) string {
+			return m.CBRMasterdata.InfrastructureCoordinatorEmail
+		},
+		equal: strings.EqualFold,
+	},
+	{
+		Billing:      "gpu_enabled",
+		Metis:        "cbrMasterdata.gpuEnabled",
+		billingValue: func(p projects.Project) string { return strconv.FormatBool(p.GPUEnabled) },
+		metisValue:   func(m metisprojects.Project) string { return strconv.FormatBool(m.CBRMasterdata.GPUEnabled) },
+	},
+	{
+		Billing:      "contains_pii_dpp_hr",
+		Metis:        "cbrMasterdata.containsPIIDPPHR",
+		billingValue: func(p projects.Project) string { return strconv.FormatBool(p.ContainsPIIDPPHR) },
+		metisValue: func(m metisprojects.Project) string {
+			return strconv.FormatBool(m.CBRMasterdata.ContainsPIIDPPHR)
+		},
+	},
+	{
+		Billing:      "contains_external_customer_data",
+		Metis:        "cbrMasterdata.containsExternalCustomerData",
+		billingValue: func(p projects.Project) string { return strconv.FormatBool(p.ContainsExternalCustomerData) },
+		metisValue: func(m metisprojects.Project) string {
+			return strconv.FormatBool(m.CBRMasterdata.ContainsExternalCustomerData)
+		},
+	},
+	certificationField("c5", "C5",
+		func(c projects.ExtCertification) bool { return c.C5 },
+		func(c metisprojects.ExternalCertifications) bool { return c.C5 }),
+	certificationField("iso", "ISO",
+		func(c projects.ExtCertification) bool { return c.ISO },
+		func(c metisprojects.ExternalCertifications) bool { return c.ISO }),
+	certificationField("pci", "PCI",
+		func(c projects.ExtCertification) bool { return c.PCI },
+		func(c metisprojects.ExternalCertifications) bool { return c.PCI }),
+	certificationField("soc1", "SOC1",
+		func(c projects.ExtCertification) bool { return c.SOC1 },
+		func(c metisprojects.ExternalCertifications) bool { return c.SOC1 }),
+	certificationField("soc2", "SOC2",
+		func(c projects.ExtCertification) bool { return c.SOC2 },
+		func(c metisprojects.ExternalCertifications) bool { return c.SOC2 }),
+	certificationField("sox", "SOX",
+		func(c projects.ExtCertification) bool { return c.SOX },
+		func(c metisprojects.ExternalCertifications) bool { return c.SOX }),
+}
+
+// certificationField builds the mapping for one external certification. A
+// project without ExtCertification is treated as having no certifications.
+func certificationField(billingName, metisName string, billingValue func(projects.ExtCertification) bool, metisValue func(metisprojects.ExternalCertifications) bool) Field {
+	return Field{
+		Billing: "ext_certification." + billingName,
+		Metis:   "cbrMasterdata.externalCertifications." + metisName,
+		billingValue: func(p projects.Project) string {
+			if p.ExtCertification == nil {
+				return strconv.FormatBool(false)
+			}
+			return strconv.FormatBool(billingValue(*p.ExtCertification))
+		},
+		metisValue: func(m metisprojects.Project) string {
+			return strconv.FormatBool(metisValue(m.CBRMasterdata.ExternalCertifications))
+		},
+	}
+}
+
+// costObjectInherited skips the comparison of the cost object name and type
+// for projects that inherit their cost object, since billing does not report
+// the inherited cost object on the project.
+func costObjectInherited(p projects.Project) bool {
+	return p.CostObject.Inherited
+}
+
+// Mismatch is a field whose value differs between billing and Metis.
+type Mismatch struct {
+	// BillingField and MetisField are the names of the field in both systems,
+	// as in Field.
+	BillingField string `json:"billing_field"`
+	MetisField   string `json:"metis_field"`
+	BillingValue string `json:"billing_value"`
+	MetisValue   string `json:"metis_value"`
+}
+
+// Compare compares the billing masterdata of a project with its Metis
+// representation field by field, and returns all mismatches in the order of
+// Fields. Email addresses, user IDs and enum-like values such as the cost
+// object type and the business criticality are compared case-insensitively.
+func Compare(billing projects.Project, metis metisprojects.Project) []Mismatch {
+	var result []Mismatch
+	for _, f := range fields {
+		if f.skip != nil && f.skip(billing) {
+			continue
+		}
+		b, m := f.billingValue(billing), f.metisValue(metis)
+		equal := f.equal
+		if equal == nil {
+			equal = func(lhs, rhs string) bool { return lhs == rhs }
+		}
+		if !equal(b, m) {
+			result = append(result, Mismatch{
+				BillingField: f.Billing,
+				MetisField:   f.Metis,
+				BillingValue: b,
+				MetisValue:   m,
+			})
+		}
+	}
+	return result
+}
+
+// ProjectDrift is the result of the comparison for a single project.
+type ProjectDrift struct {
+	ProjectID string `json:"project_id"`
+	// MissingInBilling and MissingInMetis are set if the project could not be
+	// found in the respective system. No fields are compared in this case.
+	MissingInBilling bool       `json:"missing_in_billing,omitempty"`
+	MissingInMetis   bool       `json:"missing_in_metis,omitempty"`
+	Mismatches       []Mismatch `json:"mismatches,omitempty"`
+}
+
+// HasDrift returns whether the project is missing in either system or has
+// mismatching fields.
+func (d ProjectDrift) HasDrift() bool {
+	return d.MissingInBilling || d.MissingInMetis || len(d.Mismatches) > 0
+}
+
+// Detect reads the projects with the given IDs from billing and Metis, and
+// compares them with Compare. The result contains one entry per distinct
+// project ID, sorted by project ID, including the projects without drift.
+//
+// Billing projects are read one by one; Metis projects are listed in batches
+// of MetisBatchSize. A project that is not found in one of the systems is
+// reported as missing, all other errors abort the detection.
+func Detect(ctx context.Context, billingClient, metisClient *gophercloud.ServiceClient, projectIDs []string) ([]ProjectDrift, error) {
+	ids := slices.Clone(projectIDs)
+	slices.Sort(ids)
+	ids = slices.Compact(ids)
+
+	metisProjects := make(map[string]metisprojects.Project, len(ids))
+	for batch := range slices.Chunk(ids, MetisBatchSize) {
+		opts := metisprojects.ListOpts{UUIDs: batch, Limit: MetisBatchSize}
+		page, err := metisprojects.List(metisClient, opts).AllPages(ctx)
+		if err != nil {
+			return nil, err
+		}
+		list, err := metisprojects.Extract(page)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range list {
+			metisProjects[p.UUID] = p
+		}
+	}
+
+	result := make([]ProjectDrift, 0, len(ids))
+	for _, id := range ids {
+		d := ProjectDrift{ProjectID: id}
+		billingProject, err := projects.Get(ctx, billingClient, id).Extract()
+		switch {
+		case gophercloud.ResponseCodeIs(err, http.StatusNotFound):
+			d.MissingInBilling = true
+		case err != nil:
+			return nil, err
+		}
+		metisProject, exists := metisProjects[id]
+		d.MissingInMetis = !exists
+		if !d.MissingInBilling && !d.MissingInMetis {
+			d.Mismatches = Compare(*billingProject, metisProject)
+		}
+		result = append(result, d)
+	}
+	return result, nil
+}
@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+	"github.com/gophercloud/gophercloud/v2/testhelper/client"
+
+	"github.com/sapcc/gophercloud-sapcc/v2/billing/masterdata/drift"
+	"github.com/sapcc/gophercloud-sapcc/v2/billing/masterdata/projects"
+	metisprojects "github.com/sapcc/gophercloud-sapcc/v2/metis/v1/identity/projects"
+)
+
+func TestCompare(t *testing.T) {
+	billing := projects.Project{
+		ProjectName:         "project",
+		CostObject:          projects.CostObject{Inherited: true},
+		BusinessCriticality: "dev",
+	}
+	metis := metisprojects.Project{
+		Name: "project",
+		CBRMasterdata: metisprojects.CBRMasterdata{
+			CostObjectInherited: true,
+			// not compared since the cost object is inherited
+			CostObjectName:      "inherited-from-domain",
+			BusinessCriticality: "test",
+		},
+	}
+
+	th.CheckDeepEquals(t, []drift.Mismatch{{
+		BillingField: "business_criticality",
+		MetisField:   "cbrMasterdata.businessCriticality",
+		BillingValue: "dev",
+		MetisValue:   "test",
+	}}, drift.Compare(billing, metis))
+
+	billing.BusinessCriticality = "test"
+	th.AssertEquals(t, 0, len(drift.Compare(billing, metis)))
+}
+
+func TestCompareCaseInsensitive(t *testing.T) {
+	billing := projects.Project{
+		CostObject:          projects.CostObject{Name: "123456789", Type: "IO"},
+		BusinessCriticality: "prod",
+		RevenueRelevance:    "generating",
+	}
+	metis := metisprojects.Project{
+		CBRMasterdata: metisprojects.CBRMasterdata{
+			CostObjectName:      "123456789",
+			CostObjectType:      "io",
+			BusinessCriticality: "PROD",
+			RevenueRelevance:    "Generating",
+		},
+	}
+	th.AssertEquals(t, 0, len(drift.Compare(billing, metis)))
+
+	metis.CBRMasterdata.CostObjectType = "CC"
+	th.CheckDeepEquals(t, []drift.Mismatch{{
+		BillingField: "cost_object.type",
+		MetisField:   "cbrMasterdata.costObjectType",
+		BillingValue: "IO",
+		MetisValue:   "CC",
+	}}, drift.Compare(billing, metis))
+}
+
+func TestDetect(t *testing.T) {
+	fakeServer := th.SetupHTTP()
+	defer fakeServer.Teardown()
+
+	fakeServer.Mux.HandleFunc("/masterdata/projects/p-1", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, http.MethodGet)
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, BillingProjectResponse)
+	})
+	fakeServer.Mux.HandleFunc("/masterdata/projects/p-2", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, http.MethodGet)
+		w.WriteHeader(http.StatusNotFound)
+	})
+	fakeServer.Mux.HandleFunc("/identity/project", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, http.MethodGet)
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		th.CheckDeepEquals(t, []string{"p-1", "p-2"}, r.URL.Query()["uuids"])
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, MetisListResponse)
+	})
+
+	sc := client.ServiceClient(fakeServer)
+	actual, err := drift.Detect(t.Context(), sc, sc, []string{"p-2", "p-1", "p-2"})
+	th.AssertNoErr(t, err)
+
+	expected := []drift.ProjectDrift{
+		{
+			ProjectID: "p-1",
+			Mismatches: []drift.Mismatch{
+				{
+					BillingField: "cost_object.name",
+					MetisField:   "cbrMasterdata.costObjectName",
+					BillingValue: "123456789",
+					MetisValue:   "987654321",
+				},
+				{
+					BillingField: "ext_certification.sox",
+					MetisField:   "cbrMasterdata.externalCertifications.SOX",
+					BillingValue: "false",
+					MetisValue:   "true",
+				},
+			},
+		},
+		{
+			ProjectID:        "p-2",
+			MissingInBilling: true,
+			MissingInMetis:   true,
+		},
+	}
+	th.CheckDeepEquals(t, expected, actual)
+	th.AssertEquals(t, true, actual[0].HasDrift())
+}
@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package testing
+
+const BillingProjectResponse = `
+{
+  "project_id": "p-1",
+  "project_name": "project1",
+  "domain_id": "d-1",
+  "domain_name": "domain1",
+  "cost_object": {"name": "123456789", "type": "IO", "inherited": false},
+  "responsible_primary_contact_id": "D123456",
+  "responsible_primary_contact_email": "Example@Mail.com",
+  "revenue_relevance": "generating",
+  "business_criticality": "prod",
+  "number_of_endusers": 100,
+  "gpu_enabled": 0,
+  "contains_pii_dpp_hr": 1,
+  "contains_external_customer_data": 0,
+  "ext_certification": {"c5": 1, "iso": 0, "pci": 0, "soc1": 0, "soc2": 0, "sox": 0}
+}
+`
+
+const MetisListResponse = `
+{
+  "apiVersion": "1.0",
+  "data": {
+    "kind": "project",
+    "items": [
+      {
+        "name": "project1",
+        "uuid": "p-1",
+        "domainName": "domain1",
+        "domainUUID": "d-1",
+        "cbrMasterdata": {
+          "costObjectName": "987654321",
+          "costObjectType": "IO",
+          "businessCriticality": "prod",
+          "revenueRelevance": "generating",
+          "numberOfEndusers": 100,
+          "primaryContactUserID": "d123456",
+          "primaryContactEmail": "example@mail.com",
+          "externalCertifications": {"C5": true, "SOX": true},
+          "containsPIIDPPHR": true
+        },
+        "users": []
+      }
+    ]
+  }
+}
+`
@@ -0,0 +1,168 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+// Package patch implements the read-modify-write updates of projects and
+// domains. The packages of the respective resources only provide the
+// type-specific parts in a Cycle.
+package patch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2"
+)
+
+// Attempts is the number of times Run tries to apply a change before it
+// gives up with a ConflictError.
+const Attempts = 3
+
+// Cycle provides the type-specific parts of a read-modify-write cycle for
+// records of type T that are updated with options of type O.
+type Cycle[T, O any] struct {
+	// Kind is the kind of record, e.g. "project", for error messages.
+	Kind string
+	// Get reads the record and returns it with the raw response body.
+	Get func(ctx context.Context) (*T, any, error)
+	// Version returns the fields that change with every update of the
+	// record.
+	Version func(record *T) (changedAt time.Time, changedBy string)
+	// ToOpts converts a record into update options.
+	ToOpts func(record *T) O
+	// ToMap builds the request body from the update options.
+	ToMap func(opts O) (map[string]any, error)
+	// Put sends the request body and stores the response in r.
+	Put func(ctx context.Context, body map[string]any, r *gophercloud.Result)
+	// ReadOnly lists the fields of the response body that must not be sent
+	// back in an update.
+	ReadOnly []string
+}
+
+// Run updates a record with a read-modify-write cycle: it reads the record,
+// converts it with cycle.ToOpts, calls mutate on the result and writes it
+// back. The request body is built from the mutated options, so fields that
+// mutate clears are cleared on the server. Fields of the original response
+// body that are not known to the options are sent back unchanged, so that
+// they are not dropped.
+//
+// Right before writing, the record is read again. If its version differs
+// from the first read, or the server reports a conflict, the cycle is
+// restarted with the current record and mutate is called again. After
+// Attempts unsuccessful cycles, the result contains a ConflictError.
+func Run[T, O any](ctx context.Context, id string, cycle Cycle[T, O], mutate func(*O)) gophercloud.Result {
+	for attempt := 1; ; attempt++ {
+		r := runOnce(ctx, id, cycle, mutate)
+		var conflict ConflictError
+		if !errors.As(r.Err, &conflict) || attempt >= Attempts {
+			return r
+		}
+	}
+}
+
+func runOnce[T, O any](ctx context.Context, id string, cycle Cycle[T, O], mutate func(*O)) (r gophercloud.Result) {
+	original, rawBody, err := cycle.Get(ctx)
+	if err != nil {
+		r.Err = err
+		return r
+	}
+	body, ok := rawBody.(map[string]any)
+	if !ok {
+		r.Err = fmt.Errorf("unexpected response body for %s %s: %T", cycle.Kind, id, rawBody)
+		return r
+	}
+
+	opts := cycle.ToOpts(original)
+	mutate(&opts)
+	changes, err := cycle.ToMap(opts)
+	if err != nil {
+		r.Err = err
+		return r
+	}
+	body = MergeBody(changes, body, opts, cycle.ReadOnly)
+
+	current, _, err := cycle.Get(ctx)
+	if err != nil {
+		r.Err = err
+		return r
+	}
+	originalAt, originalBy := cycle.Version(original)
+	currentAt, currentBy := cycle.Version(current)
+	if !currentAt.Equal(originalAt) || currentBy != originalBy {
+		r.Err = ConflictError{Kind: cycle.Kind, ID: id, ChangedAt: currentAt, ChangedBy: currentBy}
+		return r
+	}
+
+	cycle.Put(ctx, body, &r)
+	if gophercloud.ResponseCodeIs(r.Err, http.StatusConflict) || gophercloud.ResponseCodeIs(r.Err, http.StatusPreconditionFailed) {
+		r.Err = ConflictError{Kind: cycle.Kind, ID: id}
+	}
+	return r
+}
+
+// ConflictError is returned by Run when the record was modified
+// concurrently in each attempt.
+type ConflictError struct {
+	// Kind is the kind of record, e.g. "project".
+	Kind string
+	ID   string
+	// The ChangedAt and ChangedBy values of the concurrent change. These
+	// are empty if the conflict was reported by the server.
+	ChangedAt time.Time
+	ChangedBy string
+}
+
+// Error implements the builtin/error interface.
+func (e ConflictError) Error() string {
+	if e.ChangedAt.IsZero() {
+		return fmt.Sprintf("%s %s was modified concurrently", e.Kind, e.ID)
+	}
+	return fmt.Sprintf("%s %s was modified concurrently at %s by %s",
+		e.Kind, e.ID, e.ChangedAt.Format(time.RFC3339), e.ChangedBy)
+}
+
+// MergeBody builds the body of an update request from changes, the request
+// body built from the mutated UpdateOpts, and original, the response body of
+// the preceding read. The changes are sent as they are, so fields that the
+// mutation cleared stay cleared, even if they are omitted when empty. Only
+// fields of the original body that opts does not model are added, so that
+// fields unknown to this library are not dropped. The readOnly fields are
+// never sent back.
+func MergeBody(changes, original map[string]any, opts any, readOnly []string) map[string]any {
+	modeled := jsonFieldNames(reflect.TypeOf(opts))
+	body := maps.Clone(changes)
+	for key, value := range original {
+		_, exists := body[key]
+		if exists || modeled[key] || slices.Contains(readOnly, key) {
+			continue
+		}
+		body[key] = value
+	}
+	return body
+}
+
+// jsonFieldNames returns the JSON names of the fields of a struct type,
+// including fields that are omitted when empty.
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	result := make(map[string]bool, t.NumField())
+	for field := range t.Fields() {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = field.Name
+		}
+		result[name] = true
+	}
+	return result
+}
@@ -0,0 +1,219 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package price
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2"
+)
+
+// Key identifies the prices that apply to one metric type in one region for
+// one project type.
+type Key struct {
+	MetricType  string
+	Region      string
+	ProjectType string
+}
+
+// String returns a human-readable representation of the key.
+func (k Key) String() string {
+	return fmt.Sprintf("metric type %q in region %q for project type %q", k.MetricType, k.Region, k.ProjectType)
+}
+
+func (k Key) compare(other Key) int {
+	return cmp.Or(
+		cmp.Compare(k.MetricType, other.MetricType),
+		cmp.Compare(k.Region, other.Region),
+		cmp.Compare(k.ProjectType, other.ProjectType),
+	)
+}
+
+// PriceBook answers price lookups for arbitrary points in time. It is
+// immutable after construction and safe for concurrent use.
+//
+// The validity window of a price is the half-open interval [ValidFrom,
+// ValidTo): a price that is valid until the time another price becomes valid
+// does not overlap with the latter.
+//
+//nolint:revive // price.PriceBook reads better than price.Book
+type PriceBook struct {
+	// prices sorted by ValidFrom
+	prices map[Key][]Price
+}
+
+// LoadPriceBook lists prices with the given options and builds a PriceBook
+// from them.
+func LoadPriceBook(ctx context.Context, c *gophercloud.ServiceClient, opts ListOpts) (*PriceBook, error) {
+	page, err := List(c, opts).AllPages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	prices, err := ExtractPrices(page)
+	if err != nil {
+		return nil, err
+	}
+	return NewPriceBook(prices), nil
+}
+
+// NewPriceBook builds a PriceBook from the given prices. Inconsistent
+// validity windows do not prevent the construction; they are reported by
+// WindowErrors and by Lookup.
+func NewPriceBook(prices []Price) *PriceBook {
+	b := &PriceBook{prices: make(map[Key][]Price)}
+	for _, p := range prices {
+		key := Key{MetricType: p.MetricType, Region: p.Region, ProjectType: p.ValidForProjectType}
+		b.prices[key] = append(b.prices[key], p)
+	}
+	for _, list := range b.prices {
+		slices.SortStableFunc(list, func(lhs, rhs Price) int {
+			return cmp.Or(lhs.ValidFrom.Compare(rhs.ValidFrom), lhs.ValidTo.Compare(rhs.ValidTo))
+		})
+	}
+	return b
+}
+
+// Keys returns all keys for which the PriceBook contains prices, sorted by
+// metric type, region and project type.
+func (b *PriceBook) Keys() []Key {
+	keys := make([]Key, 0, len(b.prices))
+	for key := range b.prices {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, Key.compare)
+	return keys
+}
+
+// Prices returns all prices for the given key, sorted by ValidFrom.
+func (b *PriceBook) Prices(key Key) []Price {
+	return slices.Clone(b.prices[key])
+}
+
+// Lookup returns the price that is valid at time t for the given key. If no
+// price exists for the key's project type, prices with an empty
+// ValidForProjectType are considered to apply to all project types.
+//
+// If no price is valid at t, a PriceNotFoundError is returned. If several
+// prices are valid at t, a WindowError with Problem WindowOverlap is
+// returned.
+func (b *PriceBook) Lookup(key Key, t time.Time) (Price, error) {
+	list, exists := b.prices[key]
+	if !exists && key.ProjectType != "" {
+		list = b.prices[Key{MetricType: key.MetricType, Region: key.Region}]
+	}
+
+	var (
+		found Price
+		count int
+	)
+	for _, p := range list {
+		if !p.ValidFrom.After(t) && t.Before(p.ValidTo) {
+			if count > 0 {
+				return Price{}, WindowError{Key: key, Problem: WindowOverlap, Price: found, Next: p}
+			}
+			found = p
+			count++
+		}
+	}
+	if count == 0 {
+		return Price{}, PriceNotFoundError{Key: key, Time: t}
+	}
+	return found, nil
+}
+
+// WindowErrors checks the validity windows of all keys and returns the
+// inconsistencies, sorted by key and ValidFrom. A key is consistent if none
+// of its windows is empty or overlaps with another one, and if there are no
+// gaps between consecutive windows. Time before the first and after the last
+// window is not considered to be a gap.
+//
+// Each window is compared with the window that ends last among the windows
+// that start before it, so that windows nested into a long window and
+// overlaps with a window other than the direct predecessor are reported as
+// well. Empty windows are only reported as WindowInvalid.
+func (b *PriceBook) WindowErrors() []WindowError {
+	var result []WindowError
+	for _, key := range b.Keys() {
+		var (
+			// the price whose window ends last among the prices checked so far
+			last    Price
+			hasLast bool
+		)
+		for _, p := range b.prices[key] {
+			if !p.ValidFrom.Before(p.ValidTo) {
+				result = append(result, WindowError{Key: key, Problem: WindowInvalid, Price: p})
+				continue
+			}
+			if hasLast {
+				switch {
+				case p.ValidFrom.Before(last.ValidTo):
+					result = append(result, WindowError{Key: key, Problem: WindowOverlap, Price: last, Next: p})
+				case p.ValidFrom.After(last.ValidTo):
+					result = append(result, WindowError{Key: key, Problem: WindowGap, Price: last, Next: p})
+				}
+			}
+			if !hasLast || p.ValidTo.After(last.ValidTo) {
+				last = p
+				hasLast = true
+			}
+		}
+	}
+	return result
+}
+
+// PriceNotFoundError is returned by PriceBook.Lookup when no price is valid
+// at the requested time.
+type PriceNotFoundError struct {
+	Key  Key
+	Time time.Time
+}
+
+// Error implements the builtin/error interface.
+func (e PriceNotFoundError) Error() string {
+	return fmt.Sprintf("no price for %s at %s", e.Key, e.Time.Format(time.RFC3339))
+}
+
+// WindowProblem describes an inconsistency between validity windows.
+type WindowProblem string
+
+const (
+	// WindowOverlap means that two prices are valid at the same time.
+	WindowOverlap WindowProblem = "overlap"
+	// WindowGap means that no price is valid between two consecutive
+	// prices.
+	WindowGap WindowProblem = "gap"
+	// WindowInvalid means that ValidTo is not after ValidFrom.
+	WindowInvalid WindowProblem = "invalid"
+)
+
+// WindowError describes an inconsistency in the validity windows of the
+// prices for a key.
+type WindowError struct {
+	Key     Key
+	Problem WindowProblem
+	// Price is the affected price. For overlaps and gaps, Next is the price
+	// following it.
+	Price Price
+	Next  Price
+}
+
+// Error implements the builtin/error interface.
+func (e WindowError) Error() string {
+	window := func(p Price) string {
+		return fmt.Sprintf("[%s, %s)", p.ValidFrom.Format(time.RFC3339), p.ValidTo.Format(time.RFC3339))
+	}
+	switch e.Problem {
+	case WindowOverlap:
+		return fmt.Sprintf("prices for %s overlap: %s and %s", e.Key, window(e.Price), window(e.Next))
+	case WindowGap:
+		return fmt.Sprintf("no price for %s between %s and %s", e.Key,
+			e.Price.ValidTo.Format(time.RFC3339), e.Next.ValidFrom.Format(time.RFC3339))
+	default:
+		return fmt.Sprintf("price for %s has an empty validity window %s", e.Key, window(e.Price))
+	}
+}
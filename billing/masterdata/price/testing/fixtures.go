@@ -31,3 +31,53 @@ const ListResponse = `
   }
 ]
 `
+
+const PriceBookListResponse = `
+[
+  {
+    "PRICE_LOC": "1.0",
+    "PRICE_SEC": "1.1",
+    "VALID_FROM": "2020-01-01T00:00:00",
+    "VALID_TO": "2021-01-01T00:00:00",
+    "METRIC_TYPE": "foo",
+    "REGION": "region",
+    "VALID_FOR_PROJECT_TYPE": "quota"
+  },
+  {
+    "PRICE_LOC": "2.0",
+    "PRICE_SEC": "2.2",
+    "VALID_FROM": "2021-01-01T00:00:00",
+    "VALID_TO": "9999-12-31T00:00:00",
+    "METRIC_TYPE": "foo",
+    "REGION": "region",
+    "VALID_FOR_PROJECT_TYPE": "quota"
+  },
+  {
+    "PRICE_LOC": "3.0",
+    "PRICE_SEC": "3.3",
+    "VALID_FROM": "2020-01-01T00:00:00",
+    "VALID_TO": "2020-07-01T00:00:00",
+    "METRIC_TYPE": "bar",
+    "REGION": "region",
+    "VALID_FOR_PROJECT_TYPE": ""
+  },
+  {
+    "PRICE_LOC": "4.0",
+    "PRICE_SEC": "4.4",
+    "VALID_FROM": "2020-08-01T00:00:00",
+    "VALID_TO": "2021-02-01T00:00:00",
+    "METRIC_TYPE": "bar",
+    "REGION": "region",
+    "VALID_FOR_PROJECT_TYPE": ""
+  },
+  {
+    "PRICE_LOC": "5.0",
+    "PRICE_SEC": "5.5",
+    "VALID_FROM": "2021-01-01T00:00:00",
+    "VALID_TO": "9999-12-31T00:00:00",
+    "METRIC_TYPE": "bar",
+    "REGION": "region",
+    "VALID_FOR_PROJECT_TYPE": ""
+  }
+]
+`
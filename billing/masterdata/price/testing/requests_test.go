@@ -4,6 +4,7 @@
 package testing
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"testing"
@@ -153,3 +154,114 @@ func TestRegionListOptsNoDate(t *testing.T) {
 
 	th.CheckDeepEquals(t, priceList, actual)
 }
+
+func TestPriceBook(t *testing.T) {
+	fakeServer := th.SetupHTTP()
+	defer fakeServer.Teardown()
+
+	fakeServer.Mux.HandleFunc("/masterdata/pricelist", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, http.MethodGet)
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, PriceBookListResponse)
+	})
+
+	book, err := price.LoadPriceBook(t.Context(), client.ServiceClient(fakeServer), price.ListOpts{})
+	th.AssertNoErr(t, err)
+
+	foo := price.Key{MetricType: "foo", Region: "region", ProjectType: "quota"}
+	bar := price.Key{MetricType: "bar", Region: "region"}
+	th.CheckDeepEquals(t, []price.Key{bar, foo}, book.Keys())
+
+	// the end of a validity window is exclusive
+	p, err := book.Lookup(foo, time.Date(2020, time.December, 31, 23, 59, 59, 0, time.UTC))
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1.0, p.PriceLoc)
+	p, err = book.Lookup(foo, time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC))
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 2.2, p.PriceSec)
+
+	_, err = book.Lookup(foo, time.Date(2019, time.December, 31, 0, 0, 0, 0, time.UTC))
+	var notFound price.PriceNotFoundError
+	th.AssertEquals(t, true, errors.As(err, &notFound))
+
+	// prices without project type apply to all project types
+	p, err = book.Lookup(price.Key{MetricType: "bar", Region: "region", ProjectType: "quota"}, time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC))
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 3.0, p.PriceLoc)
+
+	_, err = book.Lookup(bar, time.Date(2021, time.January, 15, 0, 0, 0, 0, time.UTC))
+	var windowErr price.WindowError
+	th.AssertEquals(t, true, errors.As(err, &windowErr))
+	th.AssertEquals(t, price.WindowOverlap, windowErr.Problem)
+
+	windowErrs := book.WindowErrors()
+	th.AssertEquals(t, 2, len(windowErrs))
+	th.AssertEquals(t, price.WindowGap, windowErrs[0].Problem)
+	th.AssertEquals(t, 3.0, windowErrs[0].Price.PriceLoc)
+	th.AssertEquals(t, 4.0, windowErrs[0].Next.PriceLoc)
+	th.AssertEquals(t, price.WindowOverlap, windowErrs[1].Problem)
+	th.AssertEquals(t, `no price for metric type "bar" in region "region" for project type "" between 2020-07-01T00:00:00Z and 2020-08-01T00:00:00Z`, windowErrs[0].Error())
+}
+
+func TestPriceBookWindowErrors(t *testing.T) {
+	month := func(m time.Month) time.Time {
+		return time.Date(2020, m, 1, 0, 0, 0, 0, time.UTC)
+	}
+	window := func(priceLoc float64, from, to time.Month) price.Price {
+		return price.Price{MetricType: "foo", Region: "region", PriceLoc: priceLoc, ValidFrom: month(from), ValidTo: month(to)}
+	}
+	type problem struct {
+		Problem           price.WindowProblem
+		PriceLoc, NextLoc float64
+	}
+
+	testCases := []struct {
+		prices   []price.Price
+		expected []problem
+	}{
+		// consecutive windows
+		{[]price.Price{window(1, 1, 3), window(2, 3, 6)}, nil},
+		// overlap and gap between consecutive windows
+		{[]price.Price{window(1, 1, 4), window(2, 3, 6)}, []problem{
+			{price.WindowOverlap, 1, 2},
+		}},
+		{[]price.Price{window(1, 1, 3), window(2, 4, 6)}, []problem{
+			{price.WindowGap, 1, 2},
+		}},
+		// the second window is nested into the first one, so the third window
+		// overlaps with the first one, but not with its direct predecessor
+		{[]price.Price{window(1, 1, 12), window(2, 3, 4), window(3, 6, 12)}, []problem{
+			{price.WindowOverlap, 1, 2},
+			{price.WindowOverlap, 1, 3},
+		}},
+		// the end of a nested window must not be reported as a gap
+		{[]price.Price{window(1, 1, 6), window(2, 2, 3), window(3, 6, 12)}, []problem{
+			{price.WindowOverlap, 1, 2},
+		}},
+		// a long window overlaps with several later windows
+		{[]price.Price{window(1, 1, 8), window(2, 2, 4), window(3, 4, 6), window(4, 7, 12)}, []problem{
+			{price.WindowOverlap, 1, 2},
+			{price.WindowOverlap, 1, 3},
+			{price.WindowOverlap, 1, 4},
+		}},
+		// a gap is measured from the end of the longest window
+		{[]price.Price{window(1, 1, 6), window(2, 2, 4), window(3, 8, 12)}, []problem{
+			{price.WindowOverlap, 1, 2},
+			{price.WindowGap, 1, 3},
+		}},
+		// empty windows are neither overlaps nor gaps
+		{[]price.Price{window(1, 1, 3), window(2, 3, 3), window(3, 3, 6)}, []problem{
+			{price.WindowInvalid, 2, 0},
+		}},
+	}
+	for _, tc := range testCases {
+		var actual []problem
+		for _, e := range price.NewPriceBook(tc.prices).WindowErrors() {
+			actual = append(actual, problem{e.Problem, e.Price.PriceLoc, e.Next.PriceLoc})
+		}
+		th.CheckDeepEquals(t, tc.expected, actual)
+	}
+}
@@ -0,0 +1,205 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package projects
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/gophercloud/gophercloud/v2"
+
+	"github.com/sapcc/gophercloud-sapcc/v2/internal/parallel"
+)
+
+// DefaultBulkConcurrency is the number of projects that BulkUpdate processes
+// in parallel when BulkUpdateOpts.Concurrency is not set.
+const DefaultBulkConcurrency = 4
+
+// BulkOutcome describes what BulkUpdate did with a single project.
+type BulkOutcome string
+
+const (
+	// BulkOutcomeUpdated means that the project was updated, or would have
+	// been updated in a dry run.
+	BulkOutcomeUpdated BulkOutcome = "updated"
+	// BulkOutcomeUnchanged means that the mutation did not change the
+	// project.
+	BulkOutcomeUnchanged BulkOutcome = "unchanged"
+	// BulkOutcomeSkipped means that the project was already completed in the
+	// report given in BulkUpdateOpts.Resume.
+	BulkOutcomeSkipped BulkOutcome = "skipped"
+	// BulkOutcomeFailed means that the mutated project was invalid or could
+	// not be written.
+	BulkOutcomeFailed BulkOutcome = "failed"
+)
+
+// BulkResult is the outcome of BulkUpdate for a single project.
+type BulkResult struct {
+	ProjectID   string      `json:"project_id"`
+	ProjectName string      `json:"project_name"`
+	DomainID    string      `json:"domain_id"`
+	Outcome     BulkOutcome `json:"outcome"`
+	// Error is the error message if Outcome is BulkOutcomeFailed.
+	Error string `json:"error,omitempty"`
+}
+
+// BulkReport describes the outcome of a BulkUpdate run. It can be serialized
+// as JSON and passed to a later run in BulkUpdateOpts.Resume.
+type BulkReport struct {
+	DryRun bool `json:"dry_run"`
+	// Results contains one entry per selected project, sorted by project ID.
+	Results []BulkResult `json:"results"`
+}
+
+// Completed returns the IDs of the projects that do not need to be processed
+// again, i.e. that were updated, unchanged or skipped outside of a dry run.
+func (r BulkReport) Completed() []string {
+	if r.DryRun {
+		return nil
+	}
+	var result []string
+	for _, res := range r.Results {
+		if res.Outcome != BulkOutcomeFailed {
+			result = append(result, res.ProjectID)
+		}
+	}
+	return result
+}
+
+// Err returns the errors of all failed projects joined into one, or nil if no
+// project failed.
+func (r BulkReport) Err() error {
+	var errs []error
+	for _, res := range r.Results {
+		if res.Outcome == BulkOutcomeFailed {
+			errs = append(errs, fmt.Errorf("project %s: %s", res.ProjectID, res.Error))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// BulkUpdateOpts configures a BulkUpdate run.
+type BulkUpdateOpts struct {
+	// ListOpts is used to list the candidate projects.
+	ListOpts ListOpts
+	// Select returns whether a project shall be updated. If nil, all listed
+	// projects are selected.
+	Select func(Project) bool
+	// Mutate applies the change to a project. It may be called several times
+	// for the same project, see Patch.
+	Mutate func(*UpdateOpts)
+	// Concurrency limits the number of projects processed in parallel.
+	Concurrency int
+	// DryRun computes and validates the changes without applying them.
+	DryRun bool
+	// Resume is the report of a previous, interrupted run. Projects that were
+	// completed in this run are skipped.
+	Resume *BulkReport
+	// Progress, if not nil, is called once for every selected project as
+	// soon as it has been processed. Calls are not concurrent.
+	Progress func(BulkResult)
+}
+
+// BulkUpdate lists all projects with opts.ListOpts, selects those for which
+// opts.Select returns true and applies opts.Mutate to them. Projects for which
+// the mutation does not change anything are not written. Changes are written
+// with Patch, so that concurrent modifications are not overwritten.
+//
+// Errors for individual projects are collected in the report; they do not
+// abort the run. The returned error is only non-nil if listing the projects
+// fails or the context was cancelled.
+func BulkUpdate(ctx context.Context, c *gophercloud.ServiceClient, opts BulkUpdateOpts) (BulkReport, error) {
+	if opts.Mutate == nil {
+		return BulkReport{}, errors.New("missing input parameter: Mutate")
+	}
+
+	page, err := List(c, opts.ListOpts).AllPages(ctx)
+	if err != nil {
+		return BulkReport{}, err
+	}
+	allProjects, err := ExtractProjects(page)
+	if err != nil {
+		return BulkReport{}, err
+	}
+
+	completed := make(map[string]bool)
+	if opts.Resume != nil {
+		for _, id := range opts.Resume.Completed() {
+			completed[id] = true
+		}
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultBulkConcurrency
+	}
+
+	var selected []Project
+	for _, project := range allProjects {
+		if opts.Select == nil || opts.Select(project) {
+			selected = append(selected, project)
+		}
+	}
+
+	report := BulkReport{DryRun: opts.DryRun}
+	process := func(project Project) BulkResult {
+		res := BulkResult{
+			ProjectID:   project.ProjectID,
+			ProjectName: project.ProjectName,
+			DomainID:    project.DomainID,
+		}
+		if completed[project.ProjectID] {
+			res.Outcome = BulkOutcomeSkipped
+			return res
+		}
+		outcome, err := bulkUpdateProject(ctx, c, project, opts.Mutate, opts.DryRun)
+		res.Outcome = outcome
+		if err != nil {
+			res.Outcome = BulkOutcomeFailed
+			res.Error = err.Error()
+		}
+		return res
+	}
+	err = parallel.ForEach(ctx, selected, concurrency, process, func(_ Project, res BulkResult) {
+		report.Results = append(report.Results, res)
+		if opts.Progress != nil {
+			opts.Progress(res)
+		}
+	})
+
+	slices.SortFunc(report.Results, func(lhs, rhs BulkResult) int {
+		return cmp.Compare(lhs.ProjectID, rhs.ProjectID)
+	})
+	return report, err
+}
+
+func bulkUpdateProject(ctx context.Context, c *gophercloud.ServiceClient, project Project, mutate func(*UpdateOpts), dryRun bool) (BulkOutcome, error) {
+	original := ProjectToUpdateOpts(&project)
+	mutated := original
+	if original.ExtCertification != nil {
+		certs := *original.ExtCertification
+		mutated.ExtCertification = &certs
+	}
+	mutate(&mutated)
+	if reflect.DeepEqual(original, mutated) {
+		return BulkOutcomeUnchanged, nil
+	}
+
+	err := mutated.Validate()
+	if err != nil {
+		return BulkOutcomeFailed, err
+	}
+	if dryRun {
+		return BulkOutcomeUpdated, nil
+	}
+
+	err = Patch(ctx, c, project.ProjectID, mutate).Err
+	if err != nil {
+		return BulkOutcomeFailed, err
+	}
+	return BulkOutcomeUpdated, nil
+}
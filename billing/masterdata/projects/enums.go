@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package projects
+
+import "slices"
+
+// RevenueRelevance indicates if a project is directly or indirectly creating
+// revenue.
+type RevenueRelevance string
+
+const (
+	RevenueRelevanceGenerating RevenueRelevance = "generating"
+	RevenueRelevanceEnabling   RevenueRelevance = "enabling"
+	RevenueRelevanceOther      RevenueRelevance = "other"
+)
+
+// RevenueRelevances returns all allowed RevenueRelevance values.
+func RevenueRelevances() []RevenueRelevance {
+	return []RevenueRelevance{RevenueRelevanceGenerating, RevenueRelevanceEnabling, RevenueRelevanceOther}
+}
+
+// IsValid returns whether the value is empty or one of the allowed values.
+func (v RevenueRelevance) IsValid() bool {
+	return v == "" || slices.Contains(RevenueRelevances(), v)
+}
+
+// BusinessCriticality indicates how important a project is for the business.
+type BusinessCriticality string
+
+const (
+	BusinessCriticalityDev  BusinessCriticality = "dev"
+	BusinessCriticalityTest BusinessCriticality = "test"
+	BusinessCriticalityProd BusinessCriticality = "prod"
+)
+
+// BusinessCriticalities returns all allowed BusinessCriticality values.
+func BusinessCriticalities() []BusinessCriticality {
+	return []BusinessCriticality{BusinessCriticalityDev, BusinessCriticalityTest, BusinessCriticalityProd}
+}
+
+// IsValid returns whether the value is empty or one of the allowed values.
+func (v BusinessCriticality) IsValid() bool {
+	return v == "" || slices.Contains(BusinessCriticalities(), v)
+}
+
+// Environment is the build environment of a project.
+type Environment string
+
+const (
+	EnvironmentProd    Environment = "Prod"
+	EnvironmentQA      Environment = "QA"
+	EnvironmentAdmin   Environment = "Admin"
+	EnvironmentDEV     Environment = "DEV"
+	EnvironmentDemo    Environment = "Demo"
+	EnvironmentTrain   Environment = "Train"
+	EnvironmentSandbox Environment = "Sandbox"
+	EnvironmentLab     Environment = "Lab"
+	EnvironmentTest    Environment = "Test"
+)
+
+// Environments returns all allowed Environment values.
+func Environments() []Environment {
+	return []Environment{
+		EnvironmentProd, EnvironmentQA, EnvironmentAdmin, EnvironmentDEV, EnvironmentDemo,
+		EnvironmentTrain, EnvironmentSandbox, EnvironmentLab, EnvironmentTest,
+	}
+}
+
+// IsValid returns whether the value is empty or one of the allowed values.
+func (v Environment) IsValid() bool {
+	return v == "" || slices.Contains(Environments(), v)
+}
+
+// SoftLicenseMode is the software license mode of a project.
+type SoftLicenseMode string
+
+const (
+	SoftLicenseModeRevenueGenerating  SoftLicenseMode = "Revenue Generating"
+	SoftLicenseModeTrainingAndDemo    SoftLicenseMode = "Training & Demo"
+	SoftLicenseModeDevelopment        SoftLicenseMode = "Development"
+	SoftLicenseModeTestAndQS          SoftLicenseMode = "Test & QS"
+	SoftLicenseModeAdministration     SoftLicenseMode = "Administration"
+	SoftLicenseModeMake               SoftLicenseMode = "Make"
+	SoftLicenseModeVirtualizationHost SoftLicenseMode = "Virtualization-Host"
+	SoftLicenseModeProductive         SoftLicenseMode = "Productive"
+)
+
+// SoftLicenseModes returns all allowed SoftLicenseMode values.
+func SoftLicenseModes() []SoftLicenseMode {
+	return []SoftLicenseMode{
+		SoftLicenseModeRevenueGenerating, SoftLicenseModeTrainingAndDemo, SoftLicenseModeDevelopment,
+		SoftLicenseModeTestAndQS, SoftLicenseModeAdministration, SoftLicenseModeMake,
+		SoftLicenseModeVirtualizationHost, SoftLicenseModeProductive,
+	}
+}
+
+// IsValid returns whether the value is empty or one of the allowed values.
+func (v SoftLicenseMode) IsValid() bool {
+	return v == "" || slices.Contains(SoftLicenseModes(), v)
+}
+
+// TypeOfData is the input parameter for the KRITIS flag in CCIR.
+type TypeOfData string
+
+const (
+	TypeOfDataSAPBusinessProcess      TypeOfData = "SAP Business Process"
+	TypeOfDataCustomerCloudService    TypeOfData = "Customer Cloud Service"
+	TypeOfDataCustomerBusinessProcess TypeOfData = "Customer Business Process"
+	TypeOfDataTrainingAndDemoCloud    TypeOfData = "Training & Demo Cloud"
+)
+
+// TypesOfData returns all allowed TypeOfData values.
+func TypesOfData() []TypeOfData {
+	return []TypeOfData{
+		TypeOfDataSAPBusinessProcess, TypeOfDataCustomerCloudService,
+		TypeOfDataCustomerBusinessProcess, TypeOfDataTrainingAndDemoCloud,
+	}
+}
+
+// IsValid returns whether the value is empty or one of the allowed values.
+func (v TypeOfData) IsValid() bool {
+	return v == "" || slices.Contains(TypesOfData(), v)
+}
+
+// CostObjectType is the type of a cost object.
+type CostObjectType string
+
+const (
+	CostObjectTypeIO  CostObjectType = "IO"
+	CostObjectTypeCC  CostObjectType = "CC"
+	CostObjectTypeWBS CostObjectType = "WBS"
+	CostObjectTypeSO  CostObjectType = "SO"
+)
+
+// CostObjectTypes returns all allowed CostObjectType values.
+func CostObjectTypes() []CostObjectType {
+	return []CostObjectType{CostObjectTypeIO, CostObjectTypeCC, CostObjectTypeWBS, CostObjectTypeSO}
+}
+
+// IsValid returns whether the value is empty or one of the allowed values.
+func (v CostObjectType) IsValid() bool {
+	return v == "" || slices.Contains(CostObjectTypes(), v)
+}
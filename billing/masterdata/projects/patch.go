@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package projects
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2"
+
+	"github.com/sapcc/gophercloud-sapcc/v2/billing/masterdata/internal/patch"
+)
+
+// PatchAttempts is the number of times Patch tries to apply a change before
+// it gives up with a ConflictError.
+const PatchAttempts = patch.Attempts
+
+// ConflictError is returned by Patch when the project was modified
+// concurrently in each attempt.
+type ConflictError = patch.ConflictError
+
+// readOnlyFields are fields of a project that are only contained in server
+// responses and must not be sent back in an update.
+var readOnlyFields = []string{
+	"created_at", "changed_at", "changed_by",
+	"is_complete", "missing_attributes", "collector", "region",
+}
+
+// Patch updates a project with a read-modify-write cycle: it reads the
+// project, converts it with ProjectToUpdateOpts, calls mutate on the result
+// and writes it back. The request body is built from the mutated UpdateOpts,
+// so fields that mutate clears are cleared on the server. Fields of the
+// original response body that are not known to UpdateOpts are sent back
+// unchanged, so that they are not dropped.
+//
+// Right before writing, the project is read again. If ChangedAt or ChangedBy
+// differ from the first read, or the server reports a conflict, the cycle
+// is restarted with the current project and mutate is called again. After
+// PatchAttempts unsuccessful cycles, the result contains a ConflictError.
+func Patch(ctx context.Context, c *gophercloud.ServiceClient, id string, mutate func(*UpdateOpts)) (r UpdateResult) {
+	r.Result = patch.Run(ctx, id, patch.Cycle[Project, UpdateOpts]{
+		Kind: "project",
+		Get: func(ctx context.Context) (*Project, any, error) {
+			res := Get(ctx, c, id)
+			project, err := res.Extract()
+			return project, res.Body, err
+		},
+		Version: func(project *Project) (time.Time, string) {
+			return project.ChangedAt, project.ChangedBy
+		},
+		ToOpts: ProjectToUpdateOpts,
+		ToMap:  UpdateOpts.ToProjectUpdateMap,
+		Put: func(ctx context.Context, body map[string]any, r *gophercloud.Result) {
+			//nolint:bodyclose // already handled by gophercloud
+			resp, err := c.Put(ctx, updateURL(c, id), body, &r.Body, &gophercloud.RequestOpts{
+				OkCodes: []int{http.StatusOK},
+			})
+			_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+		},
+		ReadOnly: readOnlyFields,
+	}, mutate)
+	return r
+}
@@ -6,8 +6,11 @@ package projects
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"net/mail"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/gophercloud/gophercloud/v2"
@@ -182,13 +185,96 @@ func (opts UpdateOpts) MarshalJSON() ([]byte, error) {
 	return json.Marshal(res)
 }
 
-// ToProjectUpdateMap builds a request body from UpdateOpts.
+// Validate checks the UpdateOpts on the client side. It checks that enum
+// fields hold one of their allowed values, that the cost object has a name
+// and a valid type unless it is inherited, that email fields hold plain email
+// addresses, and that NumberOfEndusers is not below -1. If any check fails,
+// a ValidationError listing all invalid fields is returned.
+func (opts UpdateOpts) Validate() error {
+	var errs ValidationError
+	check := func(ok bool, field string, value any, reason string) {
+		if !ok {
+			errs.Fields = append(errs.Fields, FieldError{Field: field, Value: fmt.Sprint(value), Reason: reason})
+		}
+	}
+
+	check(RevenueRelevance(opts.RevenueRelevance).IsValid(), "revenue_relevance", opts.RevenueRelevance, "is not an allowed value")
+	check(BusinessCriticality(opts.BusinessCriticality).IsValid(), "business_criticality", opts.BusinessCriticality, "is not an allowed value")
+	check(Environment(opts.Environment).IsValid(), "environment", opts.Environment, "is not an allowed value")
+	check(SoftLicenseMode(opts.SoftLicenseMode).IsValid(), "soft_license_mode", opts.SoftLicenseMode, "is not an allowed value")
+	check(TypeOfData(opts.TypeOfData).IsValid(), "type_of_data", opts.TypeOfData, "is not an allowed value")
+	check(opts.NumberOfEndusers >= -1, "number_of_endusers", opts.NumberOfEndusers, "must be -1 (infinite) or larger")
+
+	co := opts.CostObject
+	check(co.Inherited || co.Name != "", "cost_object.name", co.Name, "is required, when the cost object is not inherited")
+	check(co.Inherited || co.Type != "", "cost_object.type", co.Type, "is required, when the cost object is not inherited")
+	check(CostObjectType(co.Type).IsValid(), "cost_object.type", co.Type, "is not an allowed value")
+
+	emails := []struct{ field, value string }{
+		{"responsible_primary_contact_email", opts.ResponsiblePrimaryContactEmail},
+		{"responsible_operator_email", opts.ResponsibleOperatorEmail},
+		{"responsible_inventory_role_email", opts.ResponsibleInventoryRoleEmail},
+		{"responsible_infrastructure_coordinator_email", opts.ResponsibleInfrastructureCoordinatorEmail},
+	}
+	for _, e := range emails {
+		check(isEmailAddress(e.value), e.field, e.value, "is not a valid email address")
+	}
+
+	if len(errs.Fields) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// isEmailAddress returns whether s is empty or a plain email address without
+// display name.
+func isEmailAddress(s string) bool {
+	if s == "" {
+		return true
+	}
+	addr, err := mail.ParseAddress(s)
+	return err == nil && addr.Address == s
+}
+
+// ToProjectUpdateMap validates the UpdateOpts and builds a request body from
+// them.
 func (opts UpdateOpts) ToProjectUpdateMap() (map[string]any, error) {
+	err := opts.Validate()
+	if err != nil {
+		return nil, err
+	}
 	return gophercloud.BuildRequestBody(opts, "")
 }
 
+// FieldError describes a single invalid field of UpdateOpts.
+type FieldError struct {
+	// The JSON name of the field, e.g. "cost_object.type".
+	Field  string
+	Value  string
+	Reason string
+}
+
+// Error implements the builtin/error interface.
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s %q %s", e.Field, e.Value, e.Reason)
+}
+
+// ValidationError is returned by UpdateOpts.Validate.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+// Error implements the builtin/error interface.
+func (e ValidationError) Error() string {
+	msgs := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		msgs[i] = f.Error()
+	}
+	return "invalid project masterdata: " + strings.Join(msgs, "; ")
+}
+
 // Update accepts a UpdateOpts struct and updates an existing project using
-// the values provided.
+// the values provided. UpdateOpts are validated before the request is sent.
 func Update(ctx context.Context, c *gophercloud.ServiceClient, id string, opts UpdateOptsBuilder) (r UpdateResult) {
 	b, err := opts.ToProjectUpdateMap()
 	if err != nil {
@@ -162,3 +162,170 @@ const UpdateResponse = `
   "is_complete": true
 }
 `
+
+// PatchGetResponse is a GET response with a field that is unknown to
+// UpdateOpts. The changed_at value is filled in by the test.
+const PatchGetResponse = `
+{
+  "project_id": "e9141fb24eee4b3e9f25ae69cda31132",
+  "project_name": "project",
+  "description": "Demos and Tests",
+  "domain_id": "2bac466eed364d8a92e477459e908736",
+  "cost_object": {
+    "inherited": true
+  },
+  "responsible_operator_email": "old-operator@mail.com",
+  "future_field": "keep-me",
+  "changed_by": "D123456",
+  "changed_at": "%s",
+  "collector": "billing.region.local",
+  "region": "region",
+  "is_complete": true
+}
+`
+
+const PatchRequest = `
+{
+  "project_id": "e9141fb24eee4b3e9f25ae69cda31132",
+  "project_name": "project",
+  "description": "Demos and Tests",
+  "domain_id": "2bac466eed364d8a92e477459e908736",
+  "cost_object": {
+    "inherited": true
+  },
+  "responsible_primary_contact_id": "",
+  "responsible_primary_contact_email": "",
+  "responsible_operator_id": "",
+  "responsible_operator_email": "new-operator@mail.com",
+  "responsible_inventory_role_id": "",
+  "responsible_inventory_role_email": "",
+  "responsible_infrastructure_coordinator_id": "",
+  "responsible_infrastructure_coordinator_email": "",
+  "revenue_relevance": "",
+  "business_criticality": "",
+  "number_of_endusers": 0,
+  "customer": "",
+  "additional_information": "",
+  "environment": "",
+  "soft_license_mode": "",
+  "type_of_data": "",
+  "gpu_enabled": 0,
+  "contains_pii_dpp_hr": 0,
+  "contains_external_customer_data": 0,
+  "future_field": "keep-me"
+}
+`
+
+// PatchClearRequest is the PUT body after the project name and the
+// responsible operator were cleared.
+const PatchClearRequest = `
+{
+  "project_id": "e9141fb24eee4b3e9f25ae69cda31132",
+  "description": "Demos and Tests",
+  "domain_id": "2bac466eed364d8a92e477459e908736",
+  "cost_object": {
+    "inherited": true
+  },
+  "responsible_primary_contact_id": "",
+  "responsible_primary_contact_email": "",
+  "responsible_operator_id": "",
+  "responsible_operator_email": "",
+  "responsible_inventory_role_id": "",
+  "responsible_inventory_role_email": "",
+  "responsible_infrastructure_coordinator_id": "",
+  "responsible_infrastructure_coordinator_email": "",
+  "revenue_relevance": "",
+  "business_criticality": "",
+  "number_of_endusers": 0,
+  "customer": "",
+  "additional_information": "",
+  "environment": "",
+  "soft_license_mode": "",
+  "type_of_data": "",
+  "gpu_enabled": 0,
+  "contains_pii_dpp_hr": 0,
+  "contains_external_customer_data": 0,
+  "future_field": "keep-me"
+}
+`
+
+const BulkListResponse = `
+[
+  {
+    "project_id": "p-a",
+    "project_name": "a",
+    "domain_id": "d-1",
+    "cost_object": {"name": "123456789", "type": "IO", "inherited": false},
+    "responsible_operator_id": "D000001",
+    "responsible_operator_email": "old@mail.com",
+    "changed_at": "2019-08-20T14:39:39.786",
+    "changed_by": "D000001"
+  },
+  {
+    "project_id": "p-b",
+    "project_name": "b",
+    "domain_id": "d-1",
+    "cost_object": {"name": "123456789", "type": "IO", "inherited": false},
+    "responsible_operator_id": "D000001",
+    "responsible_operator_email": "old@mail.com"
+  },
+  {
+    "project_id": "p-c",
+    "project_name": "c",
+    "domain_id": "d-1",
+    "cost_object": {"name": "123456789", "type": "IO", "inherited": false},
+    "responsible_operator_id": "D000002",
+    "responsible_operator_email": "other@mail.com"
+  },
+  {
+    "project_id": "p-d",
+    "project_name": "d",
+    "domain_id": "d-2",
+    "cost_object": {"inherited": false},
+    "responsible_operator_id": "D000001",
+    "responsible_operator_email": "old@mail.com"
+  }
+]
+`
+
+const BulkGetResponse = `
+{
+  "project_id": "p-a",
+  "project_name": "a",
+  "domain_id": "d-1",
+  "cost_object": {"name": "123456789", "type": "IO", "inherited": false},
+  "responsible_operator_id": "D000001",
+  "responsible_operator_email": "old@mail.com",
+  "changed_at": "2019-08-20T14:39:39.786",
+  "changed_by": "D000001"
+}
+`
+
+const BulkPatchRequest = `
+{
+  "project_id": "p-a",
+  "project_name": "a",
+  "domain_id": "d-1",
+  "cost_object": {"name": "123456789", "type": "IO", "inherited": false},
+  "description": "",
+  "responsible_primary_contact_id": "",
+  "responsible_primary_contact_email": "",
+  "responsible_operator_id": "D000003",
+  "responsible_operator_email": "new@mail.com",
+  "responsible_inventory_role_id": "",
+  "responsible_inventory_role_email": "",
+  "responsible_infrastructure_coordinator_id": "",
+  "responsible_infrastructure_coordinator_email": "",
+  "revenue_relevance": "",
+  "business_criticality": "",
+  "number_of_endusers": 0,
+  "customer": "",
+  "additional_information": "",
+  "environment": "",
+  "soft_license_mode": "",
+  "type_of_data": "",
+  "gpu_enabled": 0,
+  "contains_pii_dpp_hr": 0,
+  "contains_external_customer_data": 0
+}
+`
@@ -4,6 +4,7 @@
 package testing
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"testing"
@@ -209,3 +210,214 @@ func TestUpdate(t *testing.T) {
 
 	th.AssertDeepEquals(t, *s, updateResponse)
 }
+
+func TestUpdateOptsValidate(t *testing.T) {
+	valid := projects.UpdateOpts{
+		RevenueRelevance:               "enabling",
+		BusinessCriticality:            "prod",
+		Environment:                    "QA",
+		SoftLicenseMode:                "Test & QS",
+		TypeOfData:                     "Customer Cloud Service",
+		NumberOfEndusers:               -1,
+		ResponsiblePrimaryContactEmail: "example@mail.com",
+		CostObject: projects.CostObject{
+			Name: "123456789",
+			Type: "IO",
+		},
+	}
+	th.AssertNoErr(t, valid.Validate())
+
+	invalid := valid
+	invalid.RevenueRelevance = "lucrative"
+	invalid.Environment = "prod"
+	invalid.NumberOfEndusers = -2
+	invalid.ResponsibleOperatorEmail = "Operator <operator@mail.com>"
+	invalid.CostObject = projects.CostObject{Type: "XY"}
+
+	err := invalid.Validate()
+	var verr projects.ValidationError
+	th.AssertEquals(t, true, errors.As(err, &verr))
+
+	fields := make([]string, 0, len(verr.Fields))
+	for _, f := range verr.Fields {
+		fields = append(fields, f.Field)
+	}
+	th.CheckDeepEquals(t, []string{
+		"revenue_relevance",
+		"environment",
+		"number_of_endusers",
+		"cost_object.name",
+		"cost_object.type",
+		"responsible_operator_email",
+	}, fields)
+}
+
+func TestUpdateValidationError(t *testing.T) {
+	fakeServer := th.SetupHTTP()
+	defer fakeServer.Teardown()
+
+	// no handler is registered, so the request must not be sent at all
+	opts := projects.UpdateOpts{CostObject: projects.CostObject{Inherited: true}, BusinessCriticality: "critical"}
+	_, err := projects.Update(t.Context(), client.ServiceClient(fakeServer), "e9141fb24eee4b3e9f25ae69cda31132", opts).Extract()
+
+	var verr projects.ValidationError
+	th.AssertEquals(t, true, errors.As(err, &verr))
+	th.AssertEquals(t, "business_criticality", verr.Fields[0].Field)
+}
+
+func TestPatch(t *testing.T) {
+	fakeServer := th.SetupHTTP()
+	defer fakeServer.Teardown()
+
+	gets := 0
+	fakeServer.Mux.HandleFunc("/masterdata/projects/e9141fb24eee4b3e9f25ae69cda31132", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		w.Header().Add("Content-Type", "application/json")
+
+		switch r.Method {
+		case http.MethodGet:
+			gets++
+			// somebody else changes the project between our first two reads
+			changedAt := "2019-08-20T14:39:39.786"
+			if gets > 1 {
+				changedAt = "2019-08-21T08:00:00.000"
+			}
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, PatchGetResponse, changedAt)
+		case http.MethodPut:
+			th.TestJSONRequest(t, r, PatchRequest)
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, UpdateResponse)
+		}
+	})
+
+	mutations := 0
+	s, err := projects.Patch(t.Context(), client.ServiceClient(fakeServer), "e9141fb24eee4b3e9f25ae69cda31132", func(opts *projects.UpdateOpts) {
+		mutations++
+		th.AssertEquals(t, "old-operator@mail.com", opts.ResponsibleOperatorEmail)
+		opts.ResponsibleOperatorEmail = "new-operator@mail.com"
+	}).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertDeepEquals(t, updateResponse, *s)
+
+	th.AssertEquals(t, 2, mutations)
+	th.AssertEquals(t, 4, gets)
+}
+
+func TestPatchClearsFields(t *testing.T) {
+	fakeServer := th.SetupHTTP()
+	defer fakeServer.Teardown()
+
+	fakeServer.Mux.HandleFunc("/masterdata/projects/e9141fb24eee4b3e9f25ae69cda31132", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Content-Type", "application/json")
+
+		switch r.Method {
+		case http.MethodGet:
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, PatchGetResponse, "2019-08-20T14:39:39.786")
+		case http.MethodPut:
+			th.TestJSONRequest(t, r, PatchClearRequest)
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, UpdateResponse)
+		}
+	})
+
+	_, err := projects.Patch(t.Context(), client.ServiceClient(fakeServer), "e9141fb24eee4b3e9f25ae69cda31132", func(opts *projects.UpdateOpts) {
+		opts.ProjectName = ""
+		opts.ResponsibleOperatorEmail = ""
+	}).Extract()
+	th.AssertNoErr(t, err)
+}
+
+func TestPatchConflict(t *testing.T) {
+	fakeServer := th.SetupHTTP()
+	defer fakeServer.Teardown()
+
+	gets := 0
+	fakeServer.Mux.HandleFunc("/masterdata/projects/e9141fb24eee4b3e9f25ae69cda31132", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, http.MethodGet)
+		gets++
+		// the project changes on every read
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, PatchGetResponse, fmt.Sprintf("2019-08-20T14:39:%02d.000", gets))
+	})
+
+	_, err := projects.Patch(t.Context(), client.ServiceClient(fakeServer), "e9141fb24eee4b3e9f25ae69cda31132", func(opts *projects.UpdateOpts) {}).Extract()
+
+	var conflict projects.ConflictError
+	th.AssertEquals(t, true, errors.As(err, &conflict))
+	th.AssertEquals(t, "D123456", conflict.ChangedBy)
+	th.AssertEquals(t, 2*projects.PatchAttempts, gets)
+}
+
+func TestBulkUpdate(t *testing.T) {
+	fakeServer := th.SetupHTTP()
+	defer fakeServer.Teardown()
+
+	fakeServer.Mux.HandleFunc("/masterdata/projects", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, http.MethodGet)
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		th.CheckEquals(t, "true", r.URL.Query().Get("excludeDeleted"))
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, BulkListResponse)
+	})
+	puts := 0
+	fakeServer.Mux.HandleFunc("/masterdata/projects/p-a", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Content-Type", "application/json")
+		if r.Method == http.MethodPut {
+			puts++
+			th.TestJSONRequest(t, r, BulkPatchRequest)
+		}
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, BulkGetResponse)
+	})
+
+	opts := projects.BulkUpdateOpts{
+		ListOpts: projects.ListOpts{ExcludeDeleted: true},
+		Select: func(p projects.Project) bool {
+			return p.ResponsibleOperatorEmail == "old@mail.com"
+		},
+		Mutate: func(opts *projects.UpdateOpts) {
+			opts.ResponsibleOperatorID = "D000003"
+			opts.ResponsibleOperatorEmail = "new@mail.com"
+		},
+		Resume: &projects.BulkReport{Results: []projects.BulkResult{
+			{ProjectID: "p-b", Outcome: projects.BulkOutcomeUpdated},
+		}},
+		DryRun: true,
+	}
+
+	var progress []string
+	opts.Progress = func(res projects.BulkResult) { progress = append(progress, res.ProjectID) }
+
+	// in a dry run, nothing is written, but invalid mutations are reported
+	report, err := projects.BulkUpdate(t.Context(), client.ServiceClient(fakeServer), opts)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 0, puts)
+	th.AssertEquals(t, 3, len(progress))
+	th.AssertEquals(t, 3, len(report.Results))
+	th.AssertEquals(t, projects.BulkOutcomeUpdated, report.Results[0].Outcome)
+	th.AssertEquals(t, projects.BulkOutcomeSkipped, report.Results[1].Outcome)
+	th.AssertEquals(t, projects.BulkOutcomeFailed, report.Results[2].Outcome)
+	th.AssertEquals(t, "p-d", report.Results[2].ProjectID)
+	th.AssertEquals(t, true, report.Err() != nil)
+	th.AssertEquals(t, 0, len(report.Completed()))
+
+	opts.DryRun = false
+	report, err = projects.BulkUpdate(t.Context(), client.ServiceClient(fakeServer), opts)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, puts)
+	th.CheckDeepEquals(t, []string{"p-a", "p-b"}, report.Completed())
+
+	// a resumed run with an unchanged mutation does not write anything
+	opts.Resume = &report
+	opts.Mutate = func(opts *projects.UpdateOpts) {}
+	report, err = projects.BulkUpdate(t.Context(), client.ServiceClient(fakeServer), opts)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, puts)
+	th.AssertEquals(t, projects.BulkOutcomeSkipped, report.Results[0].Outcome)
+	th.AssertEquals(t, projects.BulkOutcomeUnchanged, report.Results[2].Outcome)
+}
@@ -0,0 +1,203 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+// Package pricecheck reconciles billing data with the prices from billing
+// masterdata.
+//
+// A billing row charges AMOUNT units of its metric type for DURATION billing
+// periods of the price, and PRICE_LOC and PRICE_SEC are the resulting
+// charges. The expected charge of a row is therefore the unit price that is
+// valid for the row's metric type and region in the billing period,
+// multiplied with AMOUNT and DURATION.
+//
+// Billing rows do not carry their billing period, so prices are looked up at
+// one point in time per billing month. Check and CheckList handle the rows of
+// a single billing month. Rows of longer ranges are fetched with
+// billing.FetchRange, which tags every row with its month, and checked with
+// CheckPeriods.
+package pricecheck
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2"
+
+	"github.com/sapcc/gophercloud-sapcc/v2/billing/masterdata/price"
+	"github.com/sapcc/gophercloud-sapcc/v2/billing/services/billing"
+)
+
+// DefaultTolerance is the relative tolerance that is used when
+// Opts.Tolerance is not set.
+const DefaultTolerance = 1e-4
+
+// Opts configures a check.
+type Opts struct {
+	// ProjectType selects the prices, see price.Key.
+	ProjectType string
+	// Time is a point in time within the billing period, e.g. the first day
+	// of the billed month. Prices are looked up at this time.
+	Time time.Time
+	// Tolerance is the maximum relative deviation of a charge from the
+	// expected charge, e.g. 0.01 for 1%.
+	Tolerance float64
+}
+
+// Problem describes why a billing row was flagged.
+type Problem string
+
+const (
+	// ProblemChargeMismatch means that the charge of the row deviates from
+	// the expected charge beyond the tolerance.
+	ProblemChargeMismatch Problem = "charge_mismatch"
+	// ProblemNoPrice means that no price is valid for the row.
+	ProblemNoPrice Problem = "no_price"
+	// ProblemAmbiguousPrice means that several prices are valid for the row,
+	// so that the expected price cannot be determined.
+	ProblemAmbiguousPrice Problem = "ambiguous_price"
+)
+
+// Discrepancy is a billing row that does not match the prices.
+type Discrepancy struct {
+	// Index is the index of the row in the checked billing data.
+	Index   int             `json:"index"`
+	Billing billing.Billing `json:"billing"`
+	Problem Problem         `json:"problem"`
+	// The unit prices from the price book, the expected charges and the
+	// differences of the actual charges to them. These are only set for
+	// ProblemChargeMismatch.
+	UnitPriceLoc      float64 `json:"unit_price_loc,omitempty"`
+	UnitPriceSec      float64 `json:"unit_price_sec,omitempty"`
+	ExpectedChargeLoc float64 `json:"expected_charge_loc,omitempty"`
+	ExpectedChargeSec float64 `json:"expected_charge_sec,omitempty"`
+	DifferenceLoc     float64 `json:"difference_loc,omitempty"`
+	DifferenceSec     float64 `json:"difference_sec,omitempty"`
+	// Message is a human-readable description of the problem.
+	Message string `json:"message"`
+}
+
+// Report is the result of a check.
+type Report struct {
+	// Checked is the number of checked billing rows.
+	Checked       int           `json:"checked"`
+	Discrepancies []Discrepancy `json:"discrepancies"`
+}
+
+// WriteJSON writes the report as a JSON document.
+func (r Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// CheckList lists billing data with the given options and checks it with
+// Check. If opts.Time is not set, it is derived from listOpts.Year and
+// listOpts.Month, or from listOpts.From.
+//
+// A range given by listOpts.From and listOpts.To must lie within one
+// calendar month, since all rows are checked against the prices of one
+// point in time. Use billing.FetchRange and CheckPeriods for longer ranges.
+func CheckList(ctx context.Context, c *gophercloud.ServiceClient, book *price.PriceBook, listOpts billing.ListOpts, opts Opts) (Report, error) {
+	if !listOpts.From.IsZero() {
+		from := listOpts.From
+		monthEnd := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, from.Location()).AddDate(0, 1, 0)
+		if listOpts.To.IsZero() || listOpts.To.After(monthEnd) {
+			return Report{}, errors.New("the range from ListOpts.From to ListOpts.To spans more than one billing month: use billing.FetchRange and CheckPeriods instead")
+		}
+	}
+	if opts.Time.IsZero() {
+		switch {
+		case listOpts.Year != 0 && listOpts.Month != 0:
+			opts.Time = time.Date(listOpts.Year, time.Month(listOpts.Month), 1, 0, 0, 0, 0, time.UTC)
+		case !listOpts.From.IsZero():
+			opts.Time = listOpts.From
+		default:
+			return Report{}, errors.New("cannot determine the billing period: set Opts.Time, ListOpts.Year and ListOpts.Month, or ListOpts.From")
+		}
+	}
+
+	page, err := billing.List(c, listOpts).AllPages(ctx)
+	if err != nil {
+		return Report{}, err
+	}
+	rows, err := billing.ExtractBillings(page)
+	if err != nil {
+		return Report{}, err
+	}
+	return Check(book, rows, opts), nil
+}
+
+// Check compares the charges of the billing rows with the charges that
+// follow from the prices in the book. All rows must belong to the billing
+// period that contains opts.Time.
+func Check(book *price.PriceBook, rows []billing.Billing, opts Opts) Report {
+	return check(book, rows, func(int) time.Time { return opts.Time }, opts)
+}
+
+// CheckPeriods is like Check, but looks up the prices of each row at the
+// start of its billing month, so that the rows may span several months.
+// opts.Time is ignored.
+func CheckPeriods(book *price.PriceBook, rows []billing.PeriodBilling, opts Opts) Report {
+	plain := make([]billing.Billing, len(rows))
+	for idx, row := range rows {
+		plain[idx] = row.Billing
+	}
+	return check(book, plain, func(idx int) time.Time {
+		return time.Date(rows[idx].Period.Year, rows[idx].Period.Month, 1, 0, 0, 0, 0, time.UTC)
+	}, opts)
+}
+
+// check implements Check and CheckPeriods. timeOf returns the time at which
+// the prices of the row with the given index are looked up.
+func check(book *price.PriceBook, rows []billing.Billing, timeOf func(idx int) time.Time, opts Opts) Report {
+	tolerance := opts.Tolerance
+	if tolerance <= 0 {
+		tolerance = DefaultTolerance
+	}
+
+	report := Report{Checked: len(rows), Discrepancies: []Discrepancy{}}
+	for idx, row := range rows {
+		key := price.Key{MetricType: row.MetricType, Region: row.Region, ProjectType: opts.ProjectType}
+		d := Discrepancy{Index: idx, Billing: row}
+
+		p, err := book.Lookup(key, timeOf(idx))
+		if err != nil {
+			d.Problem = ProblemNoPrice
+			var windowErr price.WindowError
+			if errors.As(err, &windowErr) {
+				d.Problem = ProblemAmbiguousPrice
+			}
+			d.Message = err.Error()
+			report.Discrepancies = append(report.Discrepancies, d)
+			continue
+		}
+
+		expectedLoc := p.PriceLoc * row.Amount * row.Duration
+		expectedSec := p.PriceSec * row.Amount * row.Duration
+		if withinTolerance(row.PriceLoc, expectedLoc, tolerance) && withinTolerance(row.PriceSec, expectedSec, tolerance) {
+			continue
+		}
+		d.Problem = ProblemChargeMismatch
+		d.UnitPriceLoc = p.PriceLoc
+		d.UnitPriceSec = p.PriceSec
+		d.ExpectedChargeLoc = expectedLoc
+		d.ExpectedChargeSec = expectedSec
+		d.DifferenceLoc = row.PriceLoc - expectedLoc
+		d.DifferenceSec = row.PriceSec - expectedSec
+		d.Message = fmt.Sprintf("charge of %s for amount %g and duration %g is %g/%g, but expected %g/%g at unit price %g/%g",
+			key, row.Amount, row.Duration, row.PriceLoc, row.PriceSec, expectedLoc, expectedSec, p.PriceLoc, p.PriceSec)
+		report.Discrepancies = append(report.Discrepancies, d)
+	}
+	return report
+}
+
+// withinTolerance returns whether actual deviates from expected by at most
+// the given relative tolerance. If expected is zero, actual must be zero too.
+func withinTolerance(actual, expected, tolerance float64) bool {
+	return math.Abs(actual-expected) <= tolerance*math.Abs(expected)
+}
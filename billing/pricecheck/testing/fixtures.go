@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package testing
+
+// The second row is charged with a wrong unit price, the third row with the
+// right unit price but a wrong total.
+const BillingListResponse = `
+[
+  {
+    "REGION": "region",
+    "PROJECT_ID": "p-1",
+    "METRIC_TYPE": "compute_ram_quota",
+    "AMOUNT": "12.1688",
+    "DURATION": "2.968",
+    "PRICE_LOC": "148.0796934400",
+    "PRICE_SEC": "0.0000000000"
+  },
+  {
+    "REGION": "region",
+    "PROJECT_ID": "p-1",
+    "METRIC_TYPE": "network_loadbalancers_quota",
+    "AMOUNT": "3.2648",
+    "DURATION": "2.968",
+    "PRICE_LOC": "10.6589190400",
+    "PRICE_SEC": "0.0000000000"
+  },
+  {
+    "REGION": "region",
+    "PROJECT_ID": "p-2",
+    "METRIC_TYPE": "compute_ram_quota",
+    "AMOUNT": "2",
+    "DURATION": "1",
+    "PRICE_LOC": "4.1000000000",
+    "PRICE_SEC": "0.0000000000"
+  },
+  {
+    "REGION": "region",
+    "PROJECT_ID": "p-1",
+    "METRIC_TYPE": "dns_zones_quota",
+    "AMOUNT": "1",
+    "DURATION": "1",
+    "PRICE_LOC": "1.0000000000",
+    "PRICE_SEC": "0.0000000000"
+  }
+]
+`
+
+const ExpectedReport = `{
+  "checked": 4,
+  "discrepancies": [
+    {
+      "index": 1,
+      "billing": {
+        "REGION": "region",
+        "PROJECT_ID": "p-1",
+        "PROJECT_NAME": "",
+        "OBJECT_ID": "",
+        "METRIC_TYPE": "network_loadbalancers_quota",
+        "AMOUNT": "3.2648",
+        "DURATION": "2.968",
+        "PRICE_LOC": "10.65891904",
+        "PRICE_SEC": "0",
+        "COST_OBJECT": "",
+        "COST_OBJECT_TYPE": "",
+        "CO_INHERITED": false,
+        "SEND_CC": 0
+      },
+      "problem": "charge_mismatch",
+      "unit_price_loc": 1,
+      "expected_charge_loc": 9.689926400000001,
+      "difference_loc": 0.9689926399999997,
+      "message": "charge of metric type \"network_loadbalancers_quota\" in region \"region\" for project type \"\" for amount 3.2648 and duration 2.968 is 10.65891904/0, but expected 9.689926400000001/0 at unit price 1/0"
+    },
+    {
+      "index": 2,
+      "billing": {
+        "REGION": "region",
+        "PROJECT_ID": "p-2",
+        "PROJECT_NAME": "",
+        "OBJECT_ID": "",
+        "METRIC_TYPE": "compute_ram_quota",
+        "AMOUNT": "2",
+        "DURATION": "1",
+        "PRICE_LOC": "4.1",
+        "PRICE_SEC": "0",
+        "COST_OBJECT": "",
+        "COST_OBJECT_TYPE": "",
+        "CO_INHERITED": false,
+        "SEND_CC": 0
+      },
+      "problem": "charge_mismatch",
+      "unit_price_loc": 4.1,
+      "expected_charge_loc": 8.2,
+      "difference_loc": -4.1,
+      "message": "charge of metric type \"compute_ram_quota\" in region \"region\" for project type \"\" for amount 2 and duration 1 is 4.1/0, but expected 8.2/0 at unit price 4.1/0"
+    },
+    {
+      "index": 3,
+      "billing": {
+        "REGION": "region",
+        "PROJECT_ID": "p-1",
+        "PROJECT_NAME": "",
+        "OBJECT_ID": "",
+        "METRIC_TYPE": "dns_zones_quota",
+        "AMOUNT": "1",
+        "DURATION": "1",
+        "PRICE_LOC": "1",
+        "PRICE_SEC": "0",
+        "COST_OBJECT": "",
+        "COST_OBJECT_TYPE": "",
+        "CO_INHERITED": false,
+        "SEND_CC": 0
+      },
+      "problem": "no_price",
+      "message": "no price for metric type \"dns_zones_quota\" in region \"region\" for project type \"\" at 2026-09-01T00:00:00Z"
+    }
+  ]
+}
+`
@@ -0,0 +1,123 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package testing
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+	"github.com/gophercloud/gophercloud/v2/testhelper/client"
+
+	"github.com/sapcc/gophercloud-sapcc/v2/billing/masterdata/price"
+	"github.com/sapcc/gophercloud-sapcc/v2/billing/pricecheck"
+	"github.com/sapcc/gophercloud-sapcc/v2/billing/services/billing"
+)
+
+var priceBook = price.NewPriceBook([]price.Price{
+	{
+		MetricType: "compute_ram_quota", Region: "region", PriceLoc: 4.1,
+		ValidFrom: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+		ValidTo:   time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		MetricType: "network_loadbalancers_quota", Region: "region", PriceLoc: 1,
+		ValidFrom: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+		ValidTo:   time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC),
+	},
+})
+
+func TestCheckList(t *testing.T) {
+	fakeServer := th.SetupHTTP()
+	defer fakeServer.Teardown()
+
+	fakeServer.Mux.HandleFunc("/services/billing", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, http.MethodGet)
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		th.TestFormValues(t, r, map[string]string{"year": "2026", "month": "9"})
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, BillingListResponse)
+	})
+
+	report, err := pricecheck.CheckList(t.Context(), client.ServiceClient(fakeServer), priceBook,
+		billing.ListOpts{Year: 2026, Month: 9}, pricecheck.Opts{})
+	th.AssertNoErr(t, err)
+
+	var buf bytes.Buffer
+	th.AssertNoErr(t, report.WriteJSON(&buf))
+	th.AssertEquals(t, ExpectedReport, buf.String())
+
+	// with a larger tolerance, the wrong unit price is accepted, but the
+	// wrong total is still flagged
+	report, err = pricecheck.CheckList(t.Context(), client.ServiceClient(fakeServer), priceBook,
+		billing.ListOpts{Year: 2026, Month: 9}, pricecheck.Opts{Tolerance: 0.2})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 2, len(report.Discrepancies))
+	th.AssertEquals(t, 2, report.Discrepancies[0].Index)
+	th.AssertEquals(t, pricecheck.ProblemChargeMismatch, report.Discrepancies[0].Problem)
+	th.AssertEquals(t, 8.2, report.Discrepancies[0].ExpectedChargeLoc)
+	th.AssertEquals(t, pricecheck.ProblemNoPrice, report.Discrepancies[1].Problem)
+
+	_, err = pricecheck.CheckList(t.Context(), client.ServiceClient(fakeServer), priceBook, billing.ListOpts{}, pricecheck.Opts{})
+	th.AssertErr(t, err)
+}
+
+func TestCheckListRejectsLongRanges(t *testing.T) {
+	fakeServer := th.SetupHTTP()
+	defer fakeServer.Teardown()
+
+	// spans two billing months
+	_, err := pricecheck.CheckList(t.Context(), client.ServiceClient(fakeServer), priceBook, billing.ListOpts{
+		From: time.Date(2026, time.August, 15, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2026, time.September, 15, 0, 0, 0, 0, time.UTC),
+	}, pricecheck.Opts{})
+	th.AssertErr(t, err)
+
+	// open-ended
+	_, err = pricecheck.CheckList(t.Context(), client.ServiceClient(fakeServer), priceBook, billing.ListOpts{
+		From: time.Date(2026, time.August, 15, 0, 0, 0, 0, time.UTC),
+	}, pricecheck.Opts{})
+	th.AssertErr(t, err)
+}
+
+func TestCheckPeriods(t *testing.T) {
+	// the price changes at the start of September
+	book := price.NewPriceBook([]price.Price{
+		{
+			MetricType: "compute_ram_quota", Region: "region", PriceLoc: 4,
+			ValidFrom: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+			ValidTo:   time.Date(2026, time.August, 31, 23, 59, 59, 0, time.UTC),
+		},
+		{
+			MetricType: "compute_ram_quota", Region: "region", PriceLoc: 5,
+			ValidFrom: time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC),
+			ValidTo:   time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC),
+		},
+	})
+	rows := []billing.PeriodBilling{
+		{
+			Billing: billing.Billing{Region: "region", MetricType: "compute_ram_quota", Amount: 2, Duration: 1, PriceLoc: 8},
+			Period:  billing.Period{Year: 2026, Month: time.August},
+		},
+		{
+			Billing: billing.Billing{Region: "region", MetricType: "compute_ram_quota", Amount: 2, Duration: 1, PriceLoc: 10},
+			Period:  billing.Period{Year: 2026, Month: time.September},
+		},
+		{
+			Billing: billing.Billing{Region: "region", MetricType: "compute_ram_quota", Amount: 2, Duration: 1, PriceLoc: 8},
+			Period:  billing.Period{Year: 2026, Month: time.October},
+		},
+	}
+
+	report := pricecheck.CheckPeriods(book, rows, pricecheck.Opts{})
+	th.AssertEquals(t, 3, report.Checked)
+	th.AssertEquals(t, 1, len(report.Discrepancies))
+	th.AssertEquals(t, 2, report.Discrepancies[0].Index)
+	th.AssertEquals(t, 10.0, report.Discrepancies[0].ExpectedChargeLoc)
+}
@@ -0,0 +1,167 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package billing
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2"
+
+	"github.com/sapcc/gophercloud-sapcc/v2/internal/parallel"
+)
+
+// DefaultFetchConcurrency is the number of months that FetchRange fetches in
+// parallel when FetchOpts.Concurrency is not set.
+const DefaultFetchConcurrency = 4
+
+// Period is a billing month.
+type Period struct {
+	Year  int        `json:"year"`
+	Month time.Month `json:"month"`
+}
+
+// String returns the period formatted as "YYYY-MM".
+func (p Period) String() string {
+	return fmt.Sprintf("%04d-%02d", p.Year, int(p.Month))
+}
+
+func (p Period) compare(other Period) int {
+	return cmp.Or(cmp.Compare(p.Year, other.Year), cmp.Compare(p.Month, other.Month))
+}
+
+// FetchOpts configures FetchRange.
+type FetchOpts struct {
+	// ListOpts is used for every month. Its Year, Month, From and To fields
+	// are overwritten, see FetchRange.
+	ListOpts ListOpts
+	// Concurrency limits the number of months fetched in parallel.
+	Concurrency int
+}
+
+// PeriodBilling is a billing row tagged with the month it was fetched for.
+type PeriodBilling struct {
+	Billing
+	Period Period `json:"period"`
+}
+
+// FetchResult is the result of FetchRange.
+type FetchResult struct {
+	// Rows of all successfully fetched months, sorted by period. Rows of the
+	// same period keep the order of the server response.
+	Rows []PeriodBilling
+	// Failed maps the months that could not be fetched to their error.
+	Failed map[Period]error
+}
+
+// Err returns the errors in Failed joined into one, or nil if no month
+// failed.
+func (r FetchResult) Err() error {
+	periods := make([]Period, 0, len(r.Failed))
+	for p := range r.Failed {
+		periods = append(periods, p)
+	}
+	slices.SortFunc(periods, Period.compare)
+
+	errs := make([]error, 0, len(periods))
+	for _, p := range periods {
+		errs = append(errs, fmt.Errorf("billing for %s: %w", p, r.Failed[p]))
+	}
+	return errors.Join(errs...)
+}
+
+// FetchRange lists the billing data of every calendar month that overlaps
+// with the half-open interval [from, to), using one List call per month. The
+// months are fetched in parallel.
+//
+// Months that are fully covered by the range are listed with Year and Month.
+// The first and last month are listed with From and To instead if the range
+// starts or ends within them, so that their rows only cover the part of the
+// month that is inside the range.
+//
+// Errors for individual months are collected in the result; they do not
+// abort the other months. The returned error is only non-nil if the range is
+// empty or the context was cancelled.
+func FetchRange(ctx context.Context, c *gophercloud.ServiceClient, from, to time.Time, opts FetchOpts) (FetchResult, error) {
+	if !from.Before(to) {
+		return FetchResult{}, fmt.Errorf("empty range: %s is not before %s", from.Format(time.RFC3339), to.Format(time.RFC3339))
+	}
+
+	var periods []Period
+	listOpts := make(map[Period]ListOpts)
+	for start := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, from.Location()); start.Before(to); start = start.AddDate(0, 1, 0) {
+		period := Period{Year: start.Year(), Month: start.Month()}
+		periods = append(periods, period)
+
+		end := start.AddDate(0, 1, 0)
+		o := opts.ListOpts
+		o.Year, o.Month = 0, 0
+		o.From, o.To = time.Time{}, time.Time{}
+		if from.After(start) || to.Before(end) {
+			// clip partially covered months to the range
+			o.From = latest(from, start)
+			o.To = earliest(to, end)
+		} else {
+			o.Year = period.Year
+			o.Month = int(period.Month)
+		}
+		listOpts[period] = o
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultFetchConcurrency
+	}
+
+	fetch := func(period Period) fetchedPeriod {
+		page, err := List(c, listOpts[period]).AllPages(ctx)
+		if err != nil {
+			return fetchedPeriod{err: err}
+		}
+		list, err := ExtractBillings(page)
+		return fetchedPeriod{list, err}
+	}
+
+	rows := make(map[Period][]Billing, len(periods))
+	result := FetchResult{Failed: make(map[Period]error)}
+	err := parallel.ForEach(ctx, periods, concurrency, fetch, func(period Period, f fetchedPeriod) {
+		if f.err != nil {
+			result.Failed[period] = f.err
+			return
+		}
+		rows[period] = f.rows
+	})
+
+	for _, period := range periods {
+		for _, b := range rows[period] {
+			result.Rows = append(result.Rows, PeriodBilling{Billing: b, Period: period})
+		}
+	}
+	return result, err
+}
+
+// fetchedPeriod is the result of listing the billing data of one month in
+// FetchRange.
+type fetchedPeriod struct {
+	rows []Billing
+	err  error
+}
+
+func latest(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func earliest(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
@@ -4,11 +4,15 @@
 package billing
 
 import (
+	"context"
+	"io"
 	"net/url"
 	"time"
 
 	"github.com/gophercloud/gophercloud/v2"
 	"github.com/gophercloud/gophercloud/v2/pagination"
+
+	"github.com/sapcc/gophercloud-sapcc/v2/billing/services/internal/export"
 )
 
 // ListOptsBuilder allows extensions to add additional parameters to the List
@@ -66,3 +70,16 @@ func List(c *gophercloud.ServiceClient, opts ListOptsBuilder) pagination.Pager {
 		return BillingPage{pagination.SinglePageBase(r)}
 	})
 }
+
+// Download requests billing data in the format given by opts.Format, e.g.
+// "csv" or "xlsx", and streams the raw response body into w without
+// buffering it in memory. Use this instead of List for formats other than
+// JSON, which the result types of List cannot decode. opts.Language is also
+// sent as Accept-Language header.
+func Download(ctx context.Context, c *gophercloud.ServiceClient, opts ListOpts, w io.Writer) (DownloadInfo, error) {
+	query, err := opts.ToBillingListQuery()
+	if err != nil {
+		return DownloadInfo{}, err
+	}
+	return export.Download(ctx, c, listURL(c)+query, opts.Language, w)
+}
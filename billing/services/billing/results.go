@@ -5,6 +5,8 @@ package billing
 
 import (
 	"github.com/gophercloud/gophercloud/v2/pagination"
+
+	"github.com/sapcc/gophercloud-sapcc/v2/billing/services/internal/export"
 )
 
 // Bool allows 0/1 to also become boolean.
@@ -55,3 +57,7 @@ func ExtractBillings(r pagination.Page) ([]Billing, error) {
 func ExtractBillingsInto(r pagination.Page, v any) error {
 	return r.(BillingPage).ExtractIntoSlicePtr(v, "")
 }
+
+// DownloadInfo describes the file written by Download: its content type, the
+// file name suggested by the server and its size in bytes.
+type DownloadInfo = export.Info
@@ -37,3 +37,7 @@ const ListResponse = `
   }
 ]
 `
+
+const DownloadResponse = `region;project_id;amount
+region;1a894ddae4274a32a81eee43e4e5d67e;12.1688
+`
@@ -4,8 +4,11 @@
 package testing
 
 import (
+	"bytes"
 	"fmt"
 	"net/http"
+	"slices"
+	"sync"
 	"testing"
 	"time"
 
@@ -105,3 +108,90 @@ func TestListOpts(t *testing.T) {
 
 	th.CheckDeepEquals(t, billingList, actual)
 }
+
+func TestDownload(t *testing.T) {
+	fakeServer := th.SetupHTTP()
+	defer fakeServer.Teardown()
+
+	fakeServer.Mux.HandleFunc("/services/billing", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, http.MethodGet)
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		th.TestHeader(t, r, "Accept-Language", "de")
+		th.TestFormValues(t, r, map[string]string{"format": "csv", "language": "de"})
+
+		w.Header().Add("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Add("Content-Disposition", `attachment; filename="export.csv"`)
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprint(w, DownloadResponse)
+	})
+
+	var buf bytes.Buffer
+	info, err := billing.Download(t.Context(), client.ServiceClient(fakeServer), billing.ListOpts{Format: "csv", Language: "de"}, &buf)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "text/csv", info.ContentType)
+	th.AssertEquals(t, "export.csv", info.Filename)
+	th.AssertEquals(t, int64(len(DownloadResponse)), info.Size)
+	th.AssertEquals(t, DownloadResponse, buf.String())
+}
+
+func TestFetchRange(t *testing.T) {
+	fakeServer := th.SetupHTTP()
+	defer fakeServer.Teardown()
+
+	var (
+		mutex   sync.Mutex
+		queries []string
+	)
+	fakeServer.Mux.HandleFunc("/services/billing", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, http.MethodGet)
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		mutex.Lock()
+		queries = append(queries, r.URL.RawQuery)
+		mutex.Unlock()
+
+		w.Header().Add("Content-Type", "application/json")
+		if r.URL.Query().Get("month") == "2" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, ListResponse)
+	})
+
+	from := time.Date(2026, time.January, 15, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC)
+	result, err := billing.FetchRange(t.Context(), client.ServiceClient(fakeServer), from, to, billing.FetchOpts{
+		ListOpts:    billing.ListOpts{ProjectID: "my-project", From: from},
+		Concurrency: 2,
+	})
+	th.AssertNoErr(t, err)
+
+	// January and March, but not April
+	th.AssertEquals(t, 4, len(result.Rows))
+	th.AssertEquals(t, "2026-01", result.Rows[0].Period.String())
+	th.AssertEquals(t, "2026-01", result.Rows[1].Period.String())
+	th.AssertEquals(t, "2026-03", result.Rows[2].Period.String())
+	th.CheckDeepEquals(t, billingList[1], result.Rows[3].Billing)
+
+	th.AssertEquals(t, 1, len(result.Failed))
+	th.AssertErr(t, result.Failed[billing.Period{Year: 2026, Month: time.February}])
+	th.AssertErr(t, result.Err())
+
+	// January is clipped to the range, the other months are listed in full
+	slices.Sort(queries)
+	th.CheckDeepEquals(t, []string{
+		"from=2026-01-15T00%3A00%3A00&project_id=my-project&to=2026-02-01T00%3A00%3A00",
+		"month=2&project_id=my-project&year=2026",
+		"month=3&project_id=my-project&year=2026",
+	}, queries)
+
+	// a range within a single month is clipped on both sides
+	queries = nil
+	_, err = billing.FetchRange(t.Context(), client.ServiceClient(fakeServer), from, from.AddDate(0, 0, 7), billing.FetchOpts{})
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []string{"from=2026-01-15T00%3A00%3A00&to=2026-01-22T00%3A00%3A00"}, queries)
+
+	_, err = billing.FetchRange(t.Context(), client.ServiceClient(fakeServer), to, from, billing.FetchOpts{})
+	th.AssertErr(t, err)
+}
@@ -0,0 +1,258 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package costing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2/pagination"
+)
+
+// Dimension is a property of a Costing by which costings can be grouped.
+type Dimension string
+
+const (
+	DimensionProject    Dimension = "project_id"
+	DimensionCostObject Dimension = "cost_object"
+	DimensionService    Dimension = "service"
+	DimensionMeasure    Dimension = "measure"
+	DimensionRegion     Dimension = "region"
+	// DimensionMonth groups by year and month. Its values are formatted as
+	// "YYYY-MM".
+	DimensionMonth Dimension = "month"
+)
+
+// Dimensions returns all allowed Dimension values.
+func Dimensions() []Dimension {
+	return []Dimension{
+		DimensionProject, DimensionCostObject, DimensionService,
+		DimensionMeasure, DimensionRegion, DimensionMonth,
+	}
+}
+
+// IsValid returns whether the value is one of the allowed values.
+func (d Dimension) IsValid() bool {
+	return slices.Contains(Dimensions(), d)
+}
+
+func (d Dimension) value(c Costing) string {
+	switch d {
+	case DimensionProject:
+		return c.ProjectID
+	case DimensionCostObject:
+		return c.CostObject
+	case DimensionService:
+		return c.Service
+	case DimensionMeasure:
+		return c.Measure
+	case DimensionRegion:
+		return c.Region
+	case DimensionMonth:
+		return fmt.Sprintf("%04d-%02d", c.Year, c.Month)
+	default:
+		return ""
+	}
+}
+
+// Row is the sum of all costings that share the same values for the
+// dimensions of a Pivot.
+type Row struct {
+	// Key contains the values of the dimensions, in the order of
+	// Pivot.Dimensions.
+	Key    []string `json:"key"`
+	Amount float64  `json:"amount"`
+	// AmountUnit is empty if the summed costings have different units.
+	AmountUnit string  `json:"amount_unit"`
+	PriceLoc   float64 `json:"price_loc"`
+	PriceSec   float64 `json:"price_sec"`
+	Currency   string  `json:"currency"`
+	// Count is the number of summed costings.
+	Count int `json:"count"`
+}
+
+// Pivot is the result of Aggregate.
+type Pivot struct {
+	Dimensions []Dimension `json:"dimensions"`
+	// Rows are sorted by Key.
+	Rows []Row `json:"rows"`
+}
+
+// Value returns the value of the given dimension in the row, or an empty
+// string if the pivot is not grouped by this dimension.
+func (p Pivot) Value(row Row, d Dimension) string {
+	idx := slices.Index(p.Dimensions, d)
+	if idx < 0 {
+		return ""
+	}
+	return row.Key[idx]
+}
+
+// CurrencyMismatchError is returned by Aggregate when costings with different
+// currencies would be summed up.
+type CurrencyMismatchError struct {
+	Key        []string
+	Currencies []string
+}
+
+// Error implements the builtin/error interface.
+func (e CurrencyMismatchError) Error() string {
+	return fmt.Sprintf("cannot sum costings for [%s] with different currencies: %s",
+		strings.Join(e.Key, ", "), strings.Join(e.Currencies, ", "))
+}
+
+// Aggregate groups the costings by the given dimensions and sums up their
+// amounts and prices. Without dimensions, all costings are summed up into a
+// single row. Costings with different currencies are never summed up; if a
+// group contains several currencies, a CurrencyMismatchError is returned.
+func Aggregate(costings []Costing, dimensions ...Dimension) (Pivot, error) {
+	for _, d := range dimensions {
+		if !d.IsValid() {
+			return Pivot{}, fmt.Errorf("invalid dimension: %q", d)
+		}
+	}
+
+	result := Pivot{Dimensions: slices.Clone(dimensions)}
+	index := make(map[string]int)
+	for _, c := range costings {
+		key := make([]string, len(dimensions))
+		for idx, d := range dimensions {
+			key[idx] = d.value(c)
+		}
+		// join with a separator that does not appear in the values
+		joinedKey := strings.Join(key, "\x00")
+
+		idx, exists := index[joinedKey]
+		if !exists {
+			idx = len(result.Rows)
+			index[joinedKey] = idx
+			result.Rows = append(result.Rows, Row{Key: key, AmountUnit: c.AmountUnit, Currency: c.Currency})
+		}
+		row := &result.Rows[idx]
+		if row.Currency != c.Currency {
+			currencies := []string{row.Currency, c.Currency}
+			slices.Sort(currencies)
+			return Pivot{}, CurrencyMismatchError{Key: key, Currencies: currencies}
+		}
+		if row.AmountUnit != c.AmountUnit {
+			row.AmountUnit = ""
+		}
+		row.Amount += c.Amount
+		row.PriceLoc += c.PriceLoc
+		row.PriceSec += c.PriceSec
+		row.Count++
+	}
+
+	slices.SortFunc(result.Rows, func(lhs, rhs Row) int { return slices.Compare(lhs.Key, rhs.Key) })
+	return result, nil
+}
+
+// AggregatePages reads all pages of a pager returned by ListCluster,
+// ListDomains, ListProjects or ListObjects and aggregates the costings with
+// Aggregate.
+func AggregatePages(ctx context.Context, pager pagination.Pager, dimensions ...Dimension) (Pivot, error) {
+	page, err := pager.AllPages(ctx)
+	if err != nil {
+		return Pivot{}, err
+	}
+	costings, err := ExtractCostings(page)
+	if err != nil {
+		return Pivot{}, err
+	}
+	return Aggregate(costings, dimensions...)
+}
+
+// Delta is the change of a group of costings from one month to the next.
+type Delta struct {
+	// Key contains the values of all dimensions except DimensionMonth, in
+	// the order of Pivot.Dimensions.
+	Key []string `json:"key"`
+	// Month is the later of both months, formatted as "YYYY-MM".
+	Month string `json:"month"`
+	// Previous and Current are the rows of the previous and the current
+	// month. A missing row is reported as a row with zero values.
+	Previous Row `json:"previous"`
+	Current  Row `json:"current"`
+	// The differences between Current and Previous.
+	Amount   float64 `json:"amount"`
+	PriceLoc float64 `json:"price_loc"`
+	PriceSec float64 `json:"price_sec"`
+}
+
+// MonthOverMonth computes the month-over-month deltas of every group in the
+// pivot, which must be grouped by DimensionMonth. For every group, a delta is
+// reported for each month after the earliest month of the pivot up to the
+// latest month of the pivot, unless the group has no costings in both
+// months. Deltas are sorted by Key and Month.
+func (p Pivot) MonthOverMonth() ([]Delta, error) {
+	monthIdx := slices.Index(p.Dimensions, DimensionMonth)
+	if monthIdx < 0 {
+		return nil, errors.New("pivot is not grouped by month")
+	}
+
+	type group struct {
+		key  []string
+		rows map[string]Row
+	}
+	var (
+		groups   []*group
+		index    = make(map[string]*group)
+		months   []time.Time
+		monthSet = make(map[string]bool)
+	)
+	for _, row := range p.Rows {
+		month := row.Key[monthIdx]
+		key := slices.Delete(slices.Clone(row.Key), monthIdx, monthIdx+1)
+		joinedKey := strings.Join(key, "\x00")
+		g, exists := index[joinedKey]
+		if !exists {
+			g = &group{key: key, rows: make(map[string]Row)}
+			index[joinedKey] = g
+			groups = append(groups, g)
+		}
+		g.rows[month] = row
+
+		if !monthSet[month] {
+			t, err := time.Parse("2006-01", month)
+			if err != nil {
+				return nil, fmt.Errorf("invalid month %q: %w", month, err)
+			}
+			monthSet[month] = true
+			months = append(months, t)
+		}
+	}
+	if len(months) == 0 {
+		return nil, nil
+	}
+	first := slices.MinFunc(months, time.Time.Compare)
+	last := slices.MaxFunc(months, time.Time.Compare)
+
+	slices.SortFunc(groups, func(lhs, rhs *group) int { return slices.Compare(lhs.key, rhs.key) })
+	var result []Delta
+	for _, g := range groups {
+		for t := first.AddDate(0, 1, 0); !t.After(last); t = t.AddDate(0, 1, 0) {
+			prevMonth := t.AddDate(0, -1, 0).Format("2006-01")
+			month := t.Format("2006-01")
+			prev, prevExists := g.rows[prevMonth]
+			cur, curExists := g.rows[month]
+			if !prevExists && !curExists {
+				continue
+			}
+			result = append(result, Delta{
+				Key:      g.key,
+				Month:    month,
+				Previous: prev,
+				Current:  cur,
+				Amount:   cur.Amount - prev.Amount,
+				PriceLoc: cur.PriceLoc - prev.PriceLoc,
+				PriceSec: cur.PriceSec - prev.PriceSec,
+			})
+		}
+	}
+	return result, nil
+}
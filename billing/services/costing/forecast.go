@@ -0,0 +1,378 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package costing
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2/pagination"
+)
+
+// Model is a forecasting model.
+type Model string
+
+const (
+	// ModelLinearTrend fits a straight line through the history with least
+	// squares and extends it.
+	ModelLinearTrend Model = "linear_trend"
+	// ModelSeasonalNaive repeats the value of the same month one season
+	// earlier.
+	ModelSeasonalNaive Model = "seasonal_naive"
+	// ModelMovingAverage repeats the average of the last months.
+	ModelMovingAverage Model = "moving_average"
+)
+
+// Models returns all allowed Model values.
+func Models() []Model {
+	return []Model{ModelLinearTrend, ModelSeasonalNaive, ModelMovingAverage}
+}
+
+// IsValid returns whether the value is one of the allowed values.
+func (m Model) IsValid() bool {
+	return slices.Contains(Models(), m)
+}
+
+const (
+	// DefaultMovingAverageWindow is the default ForecastOpts.Window for
+	// ModelMovingAverage.
+	DefaultMovingAverageWindow = 3
+	// DefaultSeasonLength is the default ForecastOpts.Window for
+	// ModelSeasonalNaive.
+	DefaultSeasonLength = 12
+	// DefaultConfidenceZ is the default ForecastOpts.ConfidenceZ. It
+	// corresponds to a confidence of 95% for normally distributed errors.
+	DefaultConfidenceZ = 1.96
+)
+
+// ForecastOpts configures a forecast.
+type ForecastOpts struct {
+	Model Model
+	// Months is the number of months to forecast.
+	Months int
+	// Window is the number of months that ModelMovingAverage averages over,
+	// or the season length of ModelSeasonalNaive. It is ignored for
+	// ModelLinearTrend.
+	Window int
+	// ConfidenceZ scales the confidence bands: the bands are ConfidenceZ
+	// standard deviations of the model's errors on the history wide.
+	ConfidenceZ float64
+	// Budget is the monthly budget in local currency. If positive, an Alert
+	// is reported for every forecasted month that may exceed it.
+	Budget float64
+}
+
+// Point is the cost of a single month.
+type Point struct {
+	// Month is formatted as "YYYY-MM".
+	Month string  `json:"month"`
+	Value float64 `json:"value"`
+	// Lower and Upper are the bounds of the confidence band. For historical
+	// values, and for forecasted values when Forecast.BandAvailable is false,
+	// they are equal to Value.
+	Lower float64 `json:"lower"`
+	Upper float64 `json:"upper"`
+}
+
+// AlertLevel describes how likely a budget is exceeded.
+type AlertLevel string
+
+const (
+	// AlertExpected means that the forecasted value exceeds the budget.
+	AlertExpected AlertLevel = "expected"
+	// AlertPossible means that only the upper bound of the confidence band
+	// exceeds the budget.
+	AlertPossible AlertLevel = "possible"
+)
+
+// Alert reports a forecasted month that may exceed the budget.
+type Alert struct {
+	Key    string     `json:"key"`
+	Month  string     `json:"month"`
+	Level  AlertLevel `json:"level"`
+	Value  float64    `json:"value"`
+	Upper  float64    `json:"upper"`
+	Budget float64    `json:"budget"`
+}
+
+// Forecast is the forecasted cost of a project, cost object or any other
+// group of costings.
+type Forecast struct {
+	// Key is the value of the dimension that the costings were grouped by.
+	Key   string `json:"key"`
+	Model Model  `json:"model"`
+	// History contains the actual cost of all months that the forecast is
+	// based on. Months without costings have a value of zero.
+	History []Point `json:"history"`
+	// Points contains the forecasted months.
+	Points []Point `json:"points"`
+	// BandAvailable is false if the history is too short to estimate the
+	// model's errors. The forecasted points then have no confidence band,
+	// and only AlertExpected is reported.
+	BandAvailable bool    `json:"band_available"`
+	Alerts        []Alert `json:"alerts,omitempty"`
+}
+
+// ForecastCosts groups the costings by the given dimension, usually
+// DimensionProject or DimensionCostObject, and forecasts the PriceLoc of
+// every group. The history of every group spans from the earliest to the
+// latest month in the costings. Forecasts are sorted by Key.
+func ForecastCosts(costings []Costing, dimension Dimension, opts ForecastOpts) ([]Forecast, error) {
+	if dimension == DimensionMonth {
+		return nil, fmt.Errorf("cannot forecast by dimension %q", dimension)
+	}
+	pivot, err := Aggregate(costings, dimension, DimensionMonth)
+	if err != nil {
+		return nil, err
+	}
+
+	var first, last time.Time
+	series := make(map[string]map[time.Time]float64)
+	var keys []string
+	for _, row := range pivot.Rows {
+		month, parseErr := time.Parse("2006-01", row.Key[1])
+		if parseErr != nil {
+			return nil, fmt.Errorf("invalid month %q: %w", row.Key[1], parseErr)
+		}
+		if first.IsZero() || month.Before(first) {
+			first = month
+		}
+		if month.After(last) {
+			last = month
+		}
+		key := row.Key[0]
+		if _, exists := series[key]; !exists {
+			series[key] = make(map[time.Time]float64)
+			keys = append(keys, key)
+		}
+		series[key][month] += row.PriceLoc
+	}
+
+	result := make([]Forecast, 0, len(keys))
+	for _, key := range keys {
+		var values []float64
+		for t := first; !t.After(last); t = t.AddDate(0, 1, 0) {
+			values = append(values, series[key][t])
+		}
+		f, forecastErr := ForecastSeries(key, first, values, opts)
+		if forecastErr != nil {
+			return nil, fmt.Errorf("cannot forecast %s: %w", key, forecastErr)
+		}
+		result = append(result, f)
+	}
+	return result, nil
+}
+
+// ForecastPages reads all pages of a pager returned by ListCluster,
+// ListDomains, ListProjects or ListObjects and forecasts the costings with
+// ForecastCosts. Use ListOpts.Last or ListOpts.Start and ListOpts.End to
+// select the history.
+func ForecastPages(ctx context.Context, pager pagination.Pager, dimension Dimension, opts ForecastOpts) ([]Forecast, error) {
+	page, err := pager.AllPages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	costings, err := ExtractCostings(page)
+	if err != nil {
+		return nil, err
+	}
+	return ForecastCosts(costings, dimension, opts)
+}
+
+// ForecastSeries forecasts a series of monthly values that starts at the
+// month of the given time.
+//
+// The confidence bands are derived from the standard deviation of the
+// model's one-step errors on the history, and widen with the distance from
+// the last historical month. Lower bounds are never negative. At least two
+// errors and more errors than estimated model parameters are needed for the
+// standard deviation; with a shorter history, Forecast.BandAvailable is false
+// and Lower and Upper are equal to Value.
+func ForecastSeries(key string, start time.Time, values []float64, opts ForecastOpts) (Forecast, error) {
+	if !opts.Model.IsValid() {
+		return Forecast{}, fmt.Errorf("invalid model: %q", opts.Model)
+	}
+	if opts.Months <= 0 {
+		return Forecast{}, fmt.Errorf("invalid number of months: %d", opts.Months)
+	}
+	z := opts.ConfidenceZ
+	if z == 0 {
+		z = DefaultConfidenceZ
+	}
+	window := opts.Window
+	if window <= 0 {
+		switch opts.Model {
+		case ModelMovingAverage:
+			window = DefaultMovingAverageWindow
+		case ModelSeasonalNaive:
+			window = DefaultSeasonLength
+		case ModelLinearTrend:
+		}
+	}
+
+	var (
+		predict func(h int) float64
+		spread  func(h int) float64
+	)
+	switch opts.Model {
+	case ModelLinearTrend:
+		if len(values) < 2 {
+			return Forecast{}, fmt.Errorf("model %s needs at least 2 months of history, got %d", opts.Model, len(values))
+		}
+		predict, spread = linearTrend(values)
+	case ModelSeasonalNaive:
+		if len(values) < window {
+			return Forecast{}, fmt.Errorf("model %s needs at least %d months of history, got %d", opts.Model, window, len(values))
+		}
+		predict, spread = seasonalNaive(values, window)
+	case ModelMovingAverage:
+		if len(values) < window {
+			return Forecast{}, fmt.Errorf("model %s needs at least %d months of history, got %d", opts.Model, window, len(values))
+		}
+		predict, spread = movingAverage(values, window)
+	}
+
+	start = time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, time.UTC)
+	result := Forecast{Key: key, Model: opts.Model, BandAvailable: spread != nil}
+	for idx, v := range values {
+		month := start.AddDate(0, idx, 0).Format("2006-01")
+		result.History = append(result.History, Point{Month: month, Value: v, Lower: v, Upper: v})
+	}
+	for h := 1; h <= opts.Months; h++ {
+		value := predict(h)
+		var width float64
+		if spread != nil {
+			width = z * spread(h)
+		}
+		p := Point{
+			Month: start.AddDate(0, len(values)+h-1, 0).Format("2006-01"),
+			Value: value,
+			Lower: max(value-width, 0),
+			Upper: value + width,
+		}
+		result.Points = append(result.Points, p)
+
+		if opts.Budget > 0 && p.Upper > opts.Budget {
+			level := AlertPossible
+			if p.Value > opts.Budget {
+				level = AlertExpected
+			}
+			result.Alerts = append(result.Alerts, Alert{
+				Key:    key,
+				Month:  p.Month,
+				Level:  level,
+				Value:  p.Value,
+				Upper:  p.Upper,
+				Budget: opts.Budget,
+			})
+		}
+	}
+	return result, nil
+}
+
+// linearTrend fits y = a + b*x with x = 0..n-1. The spread is the standard
+// error of a prediction at x = n-1+h, or nil if it cannot be estimated.
+func linearTrend(values []float64) (predict, spread func(int) float64) {
+	n := float64(len(values))
+	var sumX, sumY float64
+	for x, y := range values {
+		sumX += float64(x)
+		sumY += y
+	}
+	meanX, meanY := sumX/n, sumY/n
+	var sxx, sxy float64
+	for x, y := range values {
+		sxx += (float64(x) - meanX) * (float64(x) - meanX)
+		sxy += (float64(x) - meanX) * (y - meanY)
+	}
+	slope := sxy / sxx
+	intercept := meanY - slope*meanX
+
+	var residuals []float64
+	for x, y := range values {
+		residuals = append(residuals, y-(intercept+slope*float64(x)))
+	}
+	predict = func(h int) float64 {
+		return intercept + slope*(n-1+float64(h))
+	}
+	// two parameters were estimated from the data
+	sigma, ok := stddev(residuals, 2)
+	if !ok {
+		return predict, nil
+	}
+	spread = func(h int) float64 {
+		x := n - 1 + float64(h)
+		return sigma * math.Sqrt(1+1/n+(x-meanX)*(x-meanX)/sxx)
+	}
+	return predict, spread
+}
+
+// seasonalNaive repeats the last season. The spread grows with the number of
+// seasons since the last historical month, and is nil if it cannot be
+// estimated.
+func seasonalNaive(values []float64, season int) (predict, spread func(int) float64) {
+	var residuals []float64
+	for idx := season; idx < len(values); idx++ {
+		residuals = append(residuals, values[idx]-values[idx-season])
+	}
+	n := len(values)
+
+	predict = func(h int) float64 {
+		return values[n-season+(h-1)%season]
+	}
+	sigma, ok := stddev(residuals, 0)
+	if !ok {
+		return predict, nil
+	}
+	spread = func(h int) float64 {
+		return sigma * math.Sqrt(float64((h-1)/season+1))
+	}
+	return predict, spread
+}
+
+// movingAverage repeats the average of the last window values. The spread
+// grows with the square root of the distance from the last historical
+// month, and is nil if it cannot be estimated.
+func movingAverage(values []float64, window int) (predict, spread func(int) float64) {
+	var residuals []float64
+	for idx := window; idx < len(values); idx++ {
+		residuals = append(residuals, values[idx]-mean(values[idx-window:idx]))
+	}
+	avg := mean(values[len(values)-window:])
+
+	predict = func(int) float64 { return avg }
+	sigma, ok := stddev(residuals, 0)
+	if !ok {
+		return predict, nil
+	}
+	spread = func(h int) float64 { return sigma * math.Sqrt(float64(h)) }
+	return predict, spread
+}
+
+func mean(values []float64) float64 {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+// stddev returns the root mean square of the given errors, corrected for
+// the number of estimated parameters. It returns false if there are fewer
+// than two errors or not more errors than parameters, since the standard
+// deviation cannot be estimated then.
+func stddev(errs []float64, params int) (float64, bool) {
+	dof := len(errs) - params
+	if len(errs) < 2 || dof <= 0 {
+		return 0, false
+	}
+	var sum float64
+	for _, e := range errs {
+		sum += e * e
+	}
+	return math.Sqrt(sum / float64(dof)), true
+}
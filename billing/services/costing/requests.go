@@ -4,11 +4,15 @@
 package costing
 
 import (
+	"context"
+	"io"
 	"net/url"
 	"time"
 
 	"github.com/gophercloud/gophercloud/v2"
 	"github.com/gophercloud/gophercloud/v2/pagination"
+
+	"github.com/sapcc/gophercloud-sapcc/v2/billing/services/internal/export"
 )
 
 // ListOptsBuilder allows extensions to add additional parameters to the List
@@ -89,3 +93,35 @@ func list(c *gophercloud.ServiceClient, opts ListOptsBuilder, serviceURL string)
 		return CostingPage{pagination.SinglePageBase(r)}
 	})
 }
+
+// DownloadCluster requests cluster costing data in the format given by
+// opts.Format, e.g. "csv" or "xlsx", and streams the raw response body into w
+// without buffering it in memory. Use this instead of ListCluster for formats
+// other than JSON, which the result types of ListCluster cannot decode.
+// opts.Language is also sent as Accept-Language header.
+func DownloadCluster(ctx context.Context, c *gophercloud.ServiceClient, opts ListOpts, w io.Writer) (DownloadInfo, error) {
+	return download(ctx, c, opts, listURL(c, "cluster"), w)
+}
+
+// DownloadDomains is like DownloadCluster, but for domain costing data.
+func DownloadDomains(ctx context.Context, c *gophercloud.ServiceClient, opts ListOpts, w io.Writer) (DownloadInfo, error) {
+	return download(ctx, c, opts, listURL(c, "domains"), w)
+}
+
+// DownloadProjects is like DownloadCluster, but for project costing data.
+func DownloadProjects(ctx context.Context, c *gophercloud.ServiceClient, opts ListOpts, w io.Writer) (DownloadInfo, error) {
+	return download(ctx, c, opts, listURL(c, "projects"), w)
+}
+
+// DownloadObjects is like DownloadCluster, but for cost object costing data.
+func DownloadObjects(ctx context.Context, c *gophercloud.ServiceClient, opts ListOpts, w io.Writer) (DownloadInfo, error) {
+	return download(ctx, c, opts, listURL(c, "objects"), w)
+}
+
+func download(ctx context.Context, c *gophercloud.ServiceClient, opts ListOpts, serviceURL string, w io.Writer) (DownloadInfo, error) {
+	query, err := opts.ToCostingListQuery()
+	if err != nil {
+		return DownloadInfo{}, err
+	}
+	return export.Download(ctx, c, serviceURL+query, opts.Language, w)
+}
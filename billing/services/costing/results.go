@@ -5,6 +5,8 @@ package costing
 
 import (
 	"github.com/gophercloud/gophercloud/v2/pagination"
+
+	"github.com/sapcc/gophercloud-sapcc/v2/billing/services/internal/export"
 )
 
 // Costing represents a Costing Costing.
@@ -50,3 +52,7 @@ func ExtractCostings(r pagination.Page) ([]Costing, error) {
 func ExtractCostingsInto(r pagination.Page, v any) error {
 	return r.(CostingPage).ExtractIntoSlicePtr(v, "")
 }
+
+// DownloadInfo describes the file written by the Download functions: its
+// content type, the file name suggested by the server and its size in bytes.
+type DownloadInfo = export.Info
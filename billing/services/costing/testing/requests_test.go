@@ -4,8 +4,12 @@
 package testing
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"math"
 	"net/http"
+	"slices"
 	"testing"
 	"time"
 
@@ -115,3 +119,201 @@ func TestListOpts(t *testing.T) {
 
 	th.CheckDeepEquals(t, costingList, actual)
 }
+
+func TestDownload(t *testing.T) {
+	fakeServer := th.SetupHTTP()
+	defer fakeServer.Teardown()
+
+	fakeServer.Mux.HandleFunc("/services/costing/projects", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, http.MethodGet)
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		th.TestHeader(t, r, "Accept-Language", "de")
+		th.TestFormValues(t, r, map[string]string{"format": "csv", "language": "de"})
+
+		w.Header().Add("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Add("Content-Disposition", `attachment; filename="export.csv"`)
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprint(w, DownloadResponse)
+	})
+
+	var buf bytes.Buffer
+	info, err := costing.DownloadProjects(t.Context(), client.ServiceClient(fakeServer), costing.ListOpts{Format: "csv", Language: "de"}, &buf)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "text/csv", info.ContentType)
+	th.AssertEquals(t, "export.csv", info.Filename)
+	th.AssertEquals(t, int64(len(DownloadResponse)), info.Size)
+	th.AssertEquals(t, DownloadResponse, buf.String())
+}
+
+var aggregateCostings = []costing.Costing{
+	{Year: 2026, Month: 1, ProjectID: "p-1", Service: "compute", Amount: 10, AmountUnit: "h", PriceLoc: 1, PriceSec: 2, Currency: "EUR"},
+	{Year: 2026, Month: 1, ProjectID: "p-1", Service: "compute", Amount: 5, AmountUnit: "h", PriceLoc: 0.5, PriceSec: 1, Currency: "EUR"},
+	{Year: 2026, Month: 1, ProjectID: "p-2", Service: "storage", Amount: 100, AmountUnit: "GiB", PriceLoc: 4, PriceSec: 8, Currency: "EUR"},
+	{Year: 2026, Month: 2, ProjectID: "p-1", Service: "compute", Amount: 20, AmountUnit: "h", PriceLoc: 2, PriceSec: 4, Currency: "EUR"},
+	{Year: 2026, Month: 3, ProjectID: "p-2", Service: "storage", Amount: 50, AmountUnit: "GiB", PriceLoc: 2, PriceSec: 4, Currency: "EUR"},
+}
+
+func TestAggregate(t *testing.T) {
+	pivot, err := costing.Aggregate(aggregateCostings, costing.DimensionService)
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, costing.Pivot{
+		Dimensions: []costing.Dimension{costing.DimensionService},
+		Rows: []costing.Row{
+			{Key: []string{"compute"}, Amount: 35, AmountUnit: "h", PriceLoc: 3.5, PriceSec: 7, Currency: "EUR", Count: 3},
+			{Key: []string{"storage"}, Amount: 150, AmountUnit: "GiB", PriceLoc: 6, PriceSec: 12, Currency: "EUR", Count: 2},
+		},
+	}, pivot)
+	th.AssertEquals(t, "storage", pivot.Value(pivot.Rows[1], costing.DimensionService))
+
+	// mixed units are summed up, but the unit is dropped
+	pivot, err = costing.Aggregate(aggregateCostings)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, len(pivot.Rows))
+	th.AssertEquals(t, "", pivot.Rows[0].AmountUnit)
+	th.AssertEquals(t, 5, pivot.Rows[0].Count)
+
+	mixed := append(slices.Clone(aggregateCostings), costing.Costing{Year: 2026, Month: 1, Service: "compute", Currency: "USD"})
+	_, err = costing.Aggregate(mixed, costing.DimensionService)
+	var mismatch costing.CurrencyMismatchError
+	th.AssertEquals(t, true, errors.As(err, &mismatch))
+	th.CheckDeepEquals(t, []string{"EUR", "USD"}, mismatch.Currencies)
+
+	_, err = costing.Aggregate(aggregateCostings, "unknown")
+	th.AssertErr(t, err)
+}
+
+func TestMonthOverMonth(t *testing.T) {
+	pivot, err := costing.Aggregate(aggregateCostings, costing.DimensionProject, costing.DimensionMonth)
+	th.AssertNoErr(t, err)
+
+	deltas, err := pivot.MonthOverMonth()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 4, len(deltas))
+
+	// p-1: 2026-02 and 2026-03 (dropped to zero)
+	th.CheckDeepEquals(t, []string{"p-1"}, deltas[0].Key)
+	th.AssertEquals(t, "2026-02", deltas[0].Month)
+	th.AssertEquals(t, 5.0, deltas[0].Amount)
+	th.AssertEquals(t, 0.5, deltas[0].PriceLoc)
+	th.AssertEquals(t, "2026-03", deltas[1].Month)
+	th.AssertEquals(t, -2.0, deltas[1].PriceLoc)
+
+	// p-2: 2026-02 (dropped to zero) and 2026-03 (rose from zero)
+	th.CheckDeepEquals(t, []string{"p-2"}, deltas[2].Key)
+	th.AssertEquals(t, -4.0, deltas[2].PriceLoc)
+	th.AssertEquals(t, 4.0, deltas[3].PriceSec)
+
+	pivot, err = costing.Aggregate(aggregateCostings, costing.DimensionProject)
+	th.AssertNoErr(t, err)
+	_, err = pivot.MonthOverMonth()
+	th.AssertErr(t, err)
+}
+
+func TestAggregatePages(t *testing.T) {
+	fakeServer := th.SetupHTTP()
+	defer fakeServer.Teardown()
+
+	fakeServer.Mux.HandleFunc("/services/costing/objects", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, http.MethodGet)
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprint(w, ListResponse)
+	})
+
+	pivot, err := costing.AggregatePages(t.Context(), costing.ListObjects(client.ServiceClient(fakeServer), nil), costing.DimensionRegion)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, len(costingList), pivot.Rows[0].Count)
+}
+
+func TestForecastSeries(t *testing.T) {
+	start := time.Date(2026, time.January, 15, 0, 0, 0, 0, time.UTC)
+
+	// a perfect line has no errors, so the band collapses
+	f, err := costing.ForecastSeries("p-1", start, []float64{10, 20, 30, 40}, costing.ForecastOpts{
+		Model:  costing.ModelLinearTrend,
+		Months: 2,
+		Budget: 55,
+	})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, true, f.BandAvailable)
+	th.AssertEquals(t, "2026-04", f.History[3].Month)
+	th.CheckDeepEquals(t, []costing.Point{
+		{Month: "2026-05", Value: 50, Lower: 50, Upper: 50},
+		{Month: "2026-06", Value: 60, Lower: 60, Upper: 60},
+	}, f.Points)
+	th.CheckDeepEquals(t, []costing.Alert{
+		{Key: "p-1", Month: "2026-06", Level: costing.AlertExpected, Value: 60, Upper: 60, Budget: 55},
+	}, f.Alerts)
+
+	f, err = costing.ForecastSeries("p-1", start, []float64{1, 2, 3, 1, 2, 4}, costing.ForecastOpts{
+		Model:  costing.ModelSeasonalNaive,
+		Months: 4,
+		Window: 3,
+		Budget: 4.5,
+	})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, true, f.BandAvailable)
+	th.AssertEquals(t, 1.0, f.Points[0].Value)
+	th.AssertEquals(t, 4.0, f.Points[2].Value)
+	th.AssertEquals(t, 1.0, f.Points[3].Value)
+	// the errors on the history are 0, 0 and 1
+	sigma := math.Sqrt(1.0 / 3)
+	th.AssertEquals(t, true, math.Abs(4+1.96*sigma-f.Points[2].Upper) < 1e-9)
+	th.AssertEquals(t, true, math.Abs(1+1.96*sigma*math.Sqrt2-f.Points[3].Upper) < 1e-9)
+	th.AssertEquals(t, 1, len(f.Alerts))
+	th.AssertEquals(t, costing.AlertPossible, f.Alerts[0].Level)
+
+	f, err = costing.ForecastSeries("p-1", start, []float64{3, 6, 9, 6}, costing.ForecastOpts{
+		Model:  costing.ModelMovingAverage,
+		Months: 1,
+	})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 7.0, f.Points[0].Value)
+	// the only error on the history (6-6=0) is not enough to estimate the band
+	th.AssertEquals(t, false, f.BandAvailable)
+	th.AssertEquals(t, 7.0, f.Points[0].Upper)
+
+	// a one-month history has no errors at all
+	f, err = costing.ForecastSeries("p-1", start, []float64{5}, costing.ForecastOpts{
+		Model:  costing.ModelMovingAverage,
+		Months: 2,
+		Window: 1,
+		Budget: 4,
+	})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, false, f.BandAvailable)
+	th.CheckDeepEquals(t, []costing.Point{
+		{Month: "2026-02", Value: 5, Lower: 5, Upper: 5},
+		{Month: "2026-03", Value: 5, Lower: 5, Upper: 5},
+	}, f.Points)
+	th.CheckDeepEquals(t, []costing.Alert{
+		{Key: "p-1", Month: "2026-02", Level: costing.AlertExpected, Value: 5, Upper: 5, Budget: 4},
+		{Key: "p-1", Month: "2026-03", Level: costing.AlertExpected, Value: 5, Upper: 5, Budget: 4},
+	}, f.Alerts)
+
+	_, err = costing.ForecastSeries("p-1", start, []float64{1, 2}, costing.ForecastOpts{Model: costing.ModelMovingAverage, Months: 1})
+	th.AssertErr(t, err)
+	_, err = costing.ForecastSeries("p-1", start, []float64{1, 2}, costing.ForecastOpts{Model: "unknown", Months: 1})
+	th.AssertErr(t, err)
+}
+
+func TestForecastCosts(t *testing.T) {
+	forecasts, err := costing.ForecastCosts(aggregateCostings, costing.DimensionProject, costing.ForecastOpts{
+		Model:  costing.ModelMovingAverage,
+		Months: 1,
+		Window: 2,
+	})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 2, len(forecasts))
+
+	// months without costings count as zero
+	th.AssertEquals(t, "p-2", forecasts[1].Key)
+	th.AssertEquals(t, 3, len(forecasts[1].History))
+	th.AssertEquals(t, 0.0, forecasts[1].History[1].Value)
+	th.AssertEquals(t, "2026-04", forecasts[1].Points[0].Month)
+	th.AssertEquals(t, 1.0, forecasts[1].Points[0].Value)
+}
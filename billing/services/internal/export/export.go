@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+// Package export contains the download logic shared by the billing and
+// costing services.
+package export
+
+import (
+	"context"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/gophercloud/gophercloud/v2"
+)
+
+// Info describes a downloaded export file.
+type Info struct {
+	// ContentType is the media type of the file, e.g. "text/csv", without
+	// parameters.
+	ContentType string
+	// Filename is the file name suggested by the server in the
+	// Content-Disposition header. It is empty if the server did not suggest
+	// one.
+	Filename string
+	// Size is the number of bytes written.
+	Size int64
+}
+
+// Download requests the given URL and copies the response body into w
+// without buffering it. If language is not empty, it is also sent in the
+// Accept-Language header.
+func Download(ctx context.Context, c *gophercloud.ServiceClient, url, language string, w io.Writer) (Info, error) {
+	headers := map[string]string{"Accept": "*/*"}
+	if language != "" {
+		headers["Accept-Language"] = language
+	}
+
+	resp, err := c.Get(ctx, url, nil, &gophercloud.RequestOpts{
+		OkCodes:          []int{http.StatusOK},
+		MoreHeaders:      headers,
+		KeepResponseBody: true,
+	})
+	if err != nil {
+		return Info{}, err
+	}
+	defer resp.Body.Close()
+
+	var info Info
+	info.ContentType, _, err = mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil {
+		info.ContentType = resp.Header.Get("Content-Type")
+	}
+	_, params, err := mime.ParseMediaType(resp.Header.Get("Content-Disposition"))
+	if err == nil {
+		info.Filename = params["filename"]
+	}
+
+	info.Size, err = io.Copy(w, resp.Body)
+	return info, err
+}
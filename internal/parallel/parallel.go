@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+// Package parallel contains a helper for processing items with bounded
+// concurrency.
+package parallel
+
+import (
+	"context"
+	"sync"
+)
+
+// ForEach calls work for every item, with at most concurrency calls running
+// at the same time, and passes each result to collect. Calls of collect are
+// serialized, so collect may update shared state without locking. If
+// concurrency is not positive, the items are processed one at a time.
+//
+// Once ctx is cancelled, no further calls of work are started. ForEach
+// returns after all started calls have finished, with ctx.Err().
+func ForEach[T, R any](ctx context.Context, items []T, concurrency int, work func(T) R, collect func(T, R)) error {
+	var (
+		mutex sync.Mutex
+		wg    sync.WaitGroup
+		sem   = make(chan struct{}, max(concurrency, 1))
+	)
+	for _, item := range items {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Go(func() {
+			defer func() { <-sem }()
+			result := work(item)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			collect(item, result)
+		})
+	}
+	wg.Wait()
+	return ctx.Err()
+}
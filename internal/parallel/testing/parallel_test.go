@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package testing
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync/atomic"
+	"testing"
+
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+
+	"github.com/sapcc/gophercloud-sapcc/v2/internal/parallel"
+)
+
+func TestForEach(t *testing.T) {
+	var running, maxRunning atomic.Int32
+	var collected []int
+	err := parallel.ForEach(t.Context(), []int{1, 2, 3, 4, 5, 6}, 2, func(item int) int {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			prev := maxRunning.Load()
+			if n <= prev || maxRunning.CompareAndSwap(prev, n) {
+				break
+			}
+		}
+		return item * 10
+	}, func(_, result int) {
+		// collect is serialized, so no locking is needed here
+		collected = append(collected, result)
+	})
+	th.AssertNoErr(t, err)
+
+	slices.Sort(collected)
+	th.CheckDeepEquals(t, []int{10, 20, 30, 40, 50, 60}, collected)
+	th.AssertEquals(t, true, maxRunning.Load() <= 2)
+}
+
+func TestForEachCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	calls := 0
+	err := parallel.ForEach(ctx, []int{1, 2, 3}, 1, func(item int) int {
+		// the first call cancels the context, so no further calls are started
+		cancel()
+		return item
+	}, func(_, _ int) {
+		calls++
+	})
+	th.AssertEquals(t, true, errors.Is(err, context.Canceled))
+	th.AssertEquals(t, 1, calls)
+}
@@ -0,0 +1,29 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package costobjects
+
+import (
+	"iter"
+	"slices"
+
+	v1 "github.com/sapcc/gophercloud-sapcc/v2/metis/v1"
+)
+
+// Filter selects cost objects on the client side by type, which ListOpts
+// cannot express. Zero-valued fields do not filter.
+type Filter struct {
+	// Types selects cost objects of one of these types, e.g. "IO" or "CC".
+	Types []string
+}
+
+// Matches returns whether the cost object passes the filter.
+func (f Filter) Matches(co CostObject) bool {
+	return len(f.Types) == 0 || slices.Contains(f.Types, co.Type)
+}
+
+// Apply returns an iterator over the cost objects of seq that pass the
+// filter, e.g. for seq = ListAll(...).
+func (f Filter) Apply(seq iter.Seq2[CostObject, error]) iter.Seq2[CostObject, error] {
+	return v1.Filter(seq, f.Matches)
+}
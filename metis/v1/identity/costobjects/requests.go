@@ -5,6 +5,7 @@ package costobjects
 
 import (
 	"context"
+	"iter"
 	"net/http"
 
 	"github.com/gophercloud/gophercloud/v2"
@@ -20,7 +21,12 @@ type ListOptsBuilder interface {
 }
 
 // ListOpts is a structure that holds options for listing costobjects.
+// The type of a cost object cannot be selected by the server; use Filter for
+// that.
 type ListOpts struct {
+	// Name will only return costobjects with this name and may include
+	// *-wildcards.
+	Name string `q:"name"`
 	// Project will only return costobjects for the specified project uuid.
 	Project string `q:"project"`
 	// Domain will only return costobjects for the specified domain uuid.
@@ -51,6 +57,12 @@ func List(client *gophercloud.ServiceClient, opts ListOptsBuilder) pagination.Pa
 	return pagination.NewPager(client, serviceURL, v1.CreatePage())
 }
 
+// ListAll returns an iterator over all costobjects returned by List. Pages
+// are fetched lazily while iterating.
+func ListAll(ctx context.Context, client *gophercloud.ServiceClient, opts ListOptsBuilder) iter.Seq2[CostObject, error] {
+	return v1.All[CostObject](ctx, List(client, opts))
+}
+
 // Get retrieves a specific costobject based on its unique ID.
 func Get(ctx context.Context, c *gophercloud.ServiceClient, id string) (r GetResult) {
 	//nolint:bodyclose // already handled by gophercloud
@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package domains
+
+import (
+	"iter"
+
+	v1 "github.com/sapcc/gophercloud-sapcc/v2/metis/v1"
+)
+
+// Filter selects domains on the client side by cost object, which ListOpts
+// cannot express. Zero-valued fields do not filter.
+type Filter struct {
+	// CostObjectName selects domains with this cost object.
+	CostObjectName string
+}
+
+// Matches returns whether the domain passes the filter.
+func (f Filter) Matches(d Domain) bool {
+	return f.CostObjectName == "" || d.BillingMetadata.CostObjectName == f.CostObjectName
+}
+
+// Apply returns an iterator over the domains of seq that pass the filter,
+// e.g. for seq = ListAll(...).
+func (f Filter) Apply(seq iter.Seq2[Domain, error]) iter.Seq2[Domain, error] {
+	return v1.Filter(seq, f.Matches)
+}
@@ -5,6 +5,7 @@ package domains
 
 import (
 	"context"
+	"iter"
 	"net/http"
 
 	"github.com/gophercloud/gophercloud/v2"
@@ -20,7 +21,12 @@ type ListOptsBuilder interface {
 }
 
 // ListOpts is a structure that holds options for listing domain masterdata.
+// The cost object of a domain cannot be selected by the server; use Filter
+// for that.
 type ListOpts struct {
+	// Name will only return domains with this name and may include
+	// *-wildcards.
+	Name string `q:"name"`
 	// Limit will limit the number of results returned per page.
 	Limit int `q:"limit"`
 	// UUIDs will only return domains with the specified UUIDs.
@@ -47,6 +53,12 @@ func List(client *gophercloud.ServiceClient, opts ListOptsBuilder) pagination.Pa
 	return pagination.NewPager(client, serviceURL, v1.CreatePage())
 }
 
+// ListAll returns an iterator over all domains returned by List. Pages
+// are fetched lazily while iterating.
+func ListAll(ctx context.Context, client *gophercloud.ServiceClient, opts ListOptsBuilder) iter.Seq2[Domain, error] {
+	return v1.All[Domain](ctx, List(client, opts))
+}
+
 // Get retrieves a specific domain based on its unique ID.
 func Get(ctx context.Context, c *gophercloud.ServiceClient, id string) (r GetResult) {
 	//nolint:bodyclose // already handled by gophercloud
@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package projects
+
+import (
+	"iter"
+
+	v1 "github.com/sapcc/gophercloud-sapcc/v2/metis/v1"
+)
+
+// Filter selects projects on the client side by cost object, which ListOpts
+// cannot express. Zero-valued fields do not filter.
+type Filter struct {
+	// CostObjectName selects projects with this cost object.
+	CostObjectName string
+}
+
+// Matches returns whether the project passes the filter.
+func (f Filter) Matches(p Project) bool {
+	return f.CostObjectName == "" || p.CBRMasterdata.CostObjectName == f.CostObjectName
+}
+
+// Apply returns an iterator over the projects of seq that pass the filter,
+// e.g. for seq = ListAll(...).
+func (f Filter) Apply(seq iter.Seq2[Project, error]) iter.Seq2[Project, error] {
+	return v1.Filter(seq, f.Matches)
+}
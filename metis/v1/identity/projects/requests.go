@@ -5,6 +5,7 @@ package projects
 
 import (
 	"context"
+	"iter"
 	"net/http"
 
 	"github.com/gophercloud/gophercloud/v2"
@@ -20,7 +21,14 @@ type ListOptsBuilder interface {
 }
 
 // ListOpts is a structure that holds options for listing project masterdata.
+// The cost object of a project cannot be selected by the server; use Filter
+// for that.
 type ListOpts struct {
+	// Name will only return projects with this name and may include
+	// *-wildcards.
+	Name string `q:"name"`
+	// DomainIDs will only return projects in one of these domains.
+	DomainIDs []string `q:"domain"`
 	// Limit will limit the number of results returned per page.
 	Limit int `q:"limit"`
 	// UUIDs will only return projects with the specified UUIDs.
@@ -47,6 +55,12 @@ func List(client *gophercloud.ServiceClient, opts ListOptsBuilder) pagination.Pa
 	return pagination.NewPager(client, serviceURL, v1.CreatePage())
 }
 
+// ListAll returns an iterator over all projects returned by List. Pages
+// are fetched lazily while iterating.
+func ListAll(ctx context.Context, client *gophercloud.ServiceClient, opts ListOptsBuilder) iter.Seq2[Project, error] {
+	return v1.All[Project](ctx, List(client, opts))
+}
+
 // Get retrieves a specific project based on its unique ID.
 func Get(ctx context.Context, c *gophercloud.ServiceClient, id string) (r GetResult) {
 	//nolint:bodyclose // already handled by gophercloud
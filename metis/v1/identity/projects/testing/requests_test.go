@@ -4,6 +4,7 @@
 package testing
 
 import (
+	"net/http"
 	"testing"
 
 	th "github.com/gophercloud/gophercloud/v2/testhelper"
@@ -166,3 +167,44 @@ func TestListProjects(t *testing.T) {
 
 	th.CheckDeepEquals(t, expected, actual)
 }
+
+func TestListAllProjects(t *testing.T) {
+	fakeServer := th.SetupHTTP()
+	defer fakeServer.Teardown()
+	HandleListProjectsSuccessfully(t, fakeServer)
+	opts := projects.ListOpts{
+		Limit: 1,
+	}
+
+	var actual []string
+	for p, err := range projects.ListAll(t.Context(), client.ServiceClient(fakeServer), opts) {
+		th.AssertNoErr(t, err)
+		actual = append(actual, p.UUID)
+	}
+	th.CheckDeepEquals(t, []string{"project-1", "project-2"}, actual)
+}
+
+func TestListAllProjectsStopsEarly(t *testing.T) {
+	fakeServer := th.SetupHTTP()
+	defer fakeServer.Teardown()
+
+	// count the requests for the next page in front of the regular handler
+	inner := th.FakeServer{Mux: http.NewServeMux(), Server: fakeServer.Server}
+	HandleListProjectsSuccessfully(t, inner)
+	nextPageRequests := 0
+	fakeServer.Mux.HandleFunc("/identity/project", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Has("cursor") {
+			nextPageRequests++
+		}
+		inner.Mux.ServeHTTP(w, r)
+	})
+
+	var actual []string
+	for p, err := range projects.ListAll(t.Context(), client.ServiceClient(fakeServer), projects.ListOpts{Limit: 1}) {
+		th.AssertNoErr(t, err)
+		actual = append(actual, p.UUID)
+		break
+	}
+	th.CheckDeepEquals(t, []string{"project-1"}, actual)
+	th.AssertEquals(t, 0, nextPageRequests)
+}
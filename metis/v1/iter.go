@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"iter"
+
+	"github.com/gophercloud/gophercloud/v2/pagination"
+)
+
+// All returns an iterator over the items of all pages of a pager created with
+// CreatePage. Pages are fetched lazily while iterating, following the
+// nextLink of each page. Stopping the iteration early stops fetching pages.
+//
+// If a page cannot be fetched or decoded, the error is yielded with the zero
+// value of T, and the iteration ends.
+func All[T any](ctx context.Context, pager pagination.Pager) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		stopped := false
+		err := pager.EachPage(ctx, func(_ context.Context, page pagination.Page) (bool, error) {
+			var s struct {
+				Items []T `json:"items"`
+			}
+			commonPage, ok := page.(CommonPage)
+			if !ok {
+				return false, fmt.Errorf("unexpected page type %T", page)
+			}
+			err := commonPage.ExtractInto(&s)
+			if err != nil {
+				return false, err
+			}
+			for _, item := range s.Items {
+				if !yield(item, nil) {
+					stopped = true
+					return false, nil
+				}
+			}
+			return true, nil
+		})
+		if err != nil && !stopped {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
+
+// Filter returns an iterator over the items of seq for which match returns
+// true. Errors are passed through. It is used to apply client-side filters
+// for criteria that the Metis API does not support as query parameters.
+func Filter[T any](seq iter.Seq2[T, error], match func(T) bool) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for item, err := range seq {
+			if err == nil && !match(item) {
+				continue
+			}
+			if !yield(item, err) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package dns
+
+import "slices"
+
+// ZoneStatus is the status of a zone. Values that are not listed here are
+// kept as they are.
+type ZoneStatus string
+
+const (
+	ZoneStatusActive  ZoneStatus = "ACTIVE"
+	ZoneStatusPending ZoneStatus = "PENDING"
+	ZoneStatusError   ZoneStatus = "ERROR"
+	ZoneStatusDeleted ZoneStatus = "DELETED"
+)
+
+// ZoneStatuses returns all known ZoneStatus values.
+func ZoneStatuses() []ZoneStatus {
+	return []ZoneStatus{ZoneStatusActive, ZoneStatusPending, ZoneStatusError, ZoneStatusDeleted}
+}
+
+// IsValid returns whether the value is empty or one of the known values.
+func (v ZoneStatus) IsValid() bool {
+	return v == "" || slices.Contains(ZoneStatuses(), v)
+}
+
+// ZoneAction is the pending action on a zone.
+type ZoneAction string
+
+const (
+	ZoneActionNone   ZoneAction = "NONE"
+	ZoneActionCreate ZoneAction = "CREATE"
+	ZoneActionUpdate ZoneAction = "UPDATE"
+	ZoneActionDelete ZoneAction = "DELETE"
+)
+
+// ZoneActions returns all known ZoneAction values.
+func ZoneActions() []ZoneAction {
+	return []ZoneAction{ZoneActionNone, ZoneActionCreate, ZoneActionUpdate, ZoneActionDelete}
+}
+
+// IsValid returns whether the value is empty or one of the known values.
+func (v ZoneAction) IsValid() bool {
+	return v == "" || slices.Contains(ZoneActions(), v)
+}
+
+// ZoneType is the type of a zone.
+type ZoneType string
+
+const (
+	ZoneTypePrimary   ZoneType = "PRIMARY"
+	ZoneTypeSecondary ZoneType = "SECONDARY"
+)
+
+// ZoneTypes returns all known ZoneType values.
+func ZoneTypes() []ZoneType {
+	return []ZoneType{ZoneTypePrimary, ZoneTypeSecondary}
+}
+
+// IsValid returns whether the value is empty or one of the known values.
+func (v ZoneType) IsValid() bool {
+	return v == "" || slices.Contains(ZoneTypes(), v)
+}
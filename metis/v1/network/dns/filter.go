@@ -0,0 +1,39 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package dns
+
+import (
+	"iter"
+	"slices"
+
+	v1 "github.com/sapcc/gophercloud-sapcc/v2/metis/v1"
+)
+
+// Filter selects zones on the client side by pool and by the projects they
+// are shared with, which ListOpts cannot express. Zero-valued fields do not
+// filter.
+type Filter struct {
+	// Pool selects zones in this pool.
+	Pool string
+	// SharedWithProject selects zones that are shared with this project.
+	SharedWithProject string
+}
+
+// Matches returns whether the zone passes the filter.
+func (f Filter) Matches(z Zone) bool {
+	switch {
+	case f.Pool != "" && z.Pool != f.Pool:
+		return false
+	case f.SharedWithProject != "" && !slices.Contains(z.SharedWithProjects, f.SharedWithProject):
+		return false
+	default:
+		return true
+	}
+}
+
+// Apply returns an iterator over the zones of seq that pass the filter, e.g.
+// for seq = ListAll(...).
+func (f Filter) Apply(seq iter.Seq2[Zone, error]) iter.Seq2[Zone, error] {
+	return v1.Filter(seq, f.Matches)
+}
@@ -0,0 +1,220 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package dns
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/gophercloud/gophercloud/v2"
+)
+
+// Node is a zone in a Hierarchy.
+type Node struct {
+	Zone Zone
+	// Parent is nil for roots, orphans and zones in a cycle.
+	Parent *Node
+	// Children are sorted by zone name.
+	Children []*Node
+}
+
+// Hierarchy is a tree of zones built from their ParentZoneID.
+type Hierarchy struct {
+	// Roots are the zones without a parent zone, sorted by zone name.
+	Roots []*Node
+	// Orphans are the zones whose parent zone is not in the hierarchy, e.g.
+	// because it belongs to another domain. They are sorted by zone name.
+	Orphans []*Node
+	// CycleRoots are the zones in a cycle of parent zones, sorted by zone
+	// name. They have no Parent, but keep their Children outside the cycle.
+	CycleRoots []*Node
+	// Cycles lists the zone UUIDs of every cycle of parent zones, starting
+	// with the smallest UUID.
+	Cycles [][]string
+
+	byID   map[string]*Node
+	byName map[string][]*Node
+}
+
+// LoadHierarchy lists the zones with the given options, usually with
+// ListOpts.DomainID or ListOpts.ProjectID, and builds their hierarchy.
+func LoadHierarchy(ctx context.Context, client *gophercloud.ServiceClient, opts ListOpts) (*Hierarchy, error) {
+	var zones []Zone
+	for zone, err := range ListAll(ctx, client, opts) {
+		if err != nil {
+			return nil, err
+		}
+		zones = append(zones, zone)
+	}
+	return NewHierarchy(zones), nil
+}
+
+// NewHierarchy builds the hierarchy of the given zones. If several zones have
+// the same UUID, the last one wins.
+func NewHierarchy(zones []Zone) *Hierarchy {
+	h := &Hierarchy{
+		byID:   make(map[string]*Node, len(zones)),
+		byName: make(map[string][]*Node),
+	}
+	for _, zone := range zones {
+		h.byID[zone.UUID] = &Node{Zone: zone}
+	}
+	ids := slices.Sorted(maps.Keys(h.byID))
+
+	for _, id := range ids {
+		n := h.byID[id]
+		name := normalizeName(n.Zone.Name)
+		h.byName[name] = append(h.byName[name], n)
+		if n.Zone.ParentZoneID != "" {
+			n.Parent = h.byID[n.Zone.ParentZoneID]
+		}
+	}
+	h.breakCycles(ids)
+
+	for _, id := range ids {
+		n := h.byID[id]
+		switch {
+		case n.Parent != nil:
+			n.Parent.Children = append(n.Parent.Children, n)
+		case n.Zone.ParentZoneID == "":
+			h.Roots = append(h.Roots, n)
+		case h.byID[n.Zone.ParentZoneID] == nil:
+			h.Orphans = append(h.Orphans, n)
+		default:
+			// the parent was detached by breakCycles
+			h.CycleRoots = append(h.CycleRoots, n)
+		}
+	}
+	for _, n := range h.byID {
+		slices.SortFunc(n.Children, compareNodes)
+	}
+	slices.SortFunc(h.Roots, compareNodes)
+	slices.SortFunc(h.Orphans, compareNodes)
+	slices.SortFunc(h.CycleRoots, compareNodes)
+	return h
+}
+
+// breakCycles finds the cycles of parent zones, records them in h.Cycles and
+// detaches the zones in them from their parent.
+func (h *Hierarchy) breakCycles(ids []string) {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[*Node]int, len(ids))
+	var cycles [][]*Node
+	for _, id := range ids {
+		var path []*Node
+		n := h.byID[id]
+		for n != nil && state[n] == unvisited {
+			state[n] = visiting
+			path = append(path, n)
+			n = n.Parent
+		}
+		if n != nil && state[n] == visiting {
+			cycles = append(cycles, path[slices.Index(path, n):])
+		}
+		for _, p := range path {
+			state[p] = done
+		}
+	}
+
+	for _, cycle := range cycles {
+		zoneIDs := make([]string, len(cycle))
+		for idx, n := range cycle {
+			zoneIDs[idx] = n.Zone.UUID
+		}
+		start := slices.Index(zoneIDs, slices.Min(zoneIDs))
+		h.Cycles = append(h.Cycles, slices.Concat(zoneIDs[start:], zoneIDs[:start]))
+		for _, n := range cycle {
+			n.Parent = nil
+		}
+	}
+}
+
+// Zone returns the zone with the given UUID.
+func (h *Hierarchy) Zone(id string) (*Node, bool) {
+	n, exists := h.byID[id]
+	return n, exists
+}
+
+// ZonesByName returns the zones with the given name, sorted by UUID. The name
+// is matched case-insensitively, with or without trailing dot.
+func (h *Hierarchy) ZonesByName(name string) []*Node {
+	return slices.Clone(h.byName[normalizeName(name)])
+}
+
+// ClosestZone returns the zone with the longest name that equals or encloses
+// the given FQDN, e.g. "example.com." for "www.example.com". The owner of the
+// zone is found in Zone.ProjectID and Zone.DomainID. If several zones have
+// that name, the one with the smallest UUID is returned.
+func (h *Hierarchy) ClosestZone(fqdn string) (*Node, bool) {
+	name := normalizeName(fqdn)
+	for name != "" {
+		if nodes := h.byName[name]; len(nodes) > 0 {
+			return nodes[0], true
+		}
+		_, name, _ = strings.Cut(name, ".")
+	}
+	return nil, false
+}
+
+// VisibleTo returns the sorted IDs of the projects that can see the zone with
+// the given UUID: the project that owns the zone and the projects that it is
+// shared with. It returns nil if the zone is not in the hierarchy.
+func (h *Hierarchy) VisibleTo(id string) []string {
+	n, exists := h.byID[id]
+	if !exists {
+		return nil
+	}
+	var result []string
+	if n.Zone.ProjectID != "" {
+		result = append(result, n.Zone.ProjectID)
+	}
+	result = append(result, n.Zone.SharedWithProjects...)
+	slices.Sort(result)
+	return slices.Compact(result)
+}
+
+// Walk calls fn for every zone, starting from Roots, Orphans and CycleRoots in
+// that order, parents before children, with the depth of the zone below its
+// root. If fn returns false, the children of that zone are skipped.
+func (h *Hierarchy) Walk(fn func(n *Node, depth int) bool) {
+	var walk func(n *Node, depth int)
+	walk = func(n *Node, depth int) {
+		if !fn(n, depth) {
+			return
+		}
+		for _, child := range n.Children {
+			walk(child, depth+1)
+		}
+	}
+	for _, n := range h.Roots {
+		walk(n, 0)
+	}
+	for _, n := range h.Orphans {
+		walk(n, 0)
+	}
+	for _, n := range h.CycleRoots {
+		walk(n, 0)
+	}
+}
+
+// normalizeName returns the zone name in lower case with a trailing dot,
+// which is how Metis reports zone names.
+func normalizeName(name string) string {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	if name == "" {
+		return ""
+	}
+	return name + "."
+}
+
+func compareNodes(lhs, rhs *Node) int {
+	return cmp.Or(strings.Compare(lhs.Zone.Name, rhs.Zone.Name), strings.Compare(lhs.Zone.UUID, rhs.Zone.UUID))
+}
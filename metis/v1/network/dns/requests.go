@@ -5,7 +5,10 @@ package dns
 
 import (
 	"context"
+	"iter"
 	"net/http"
+	"net/url"
+	"time"
 
 	"github.com/gophercloud/gophercloud/v2"
 	"github.com/gophercloud/gophercloud/v2/pagination"
@@ -20,6 +23,8 @@ type ListOptsBuilder interface {
 }
 
 // ListOpts is a structure that holds options for listing DNS zones.
+// The pool and the projects a zone is shared with cannot be selected by the
+// server; use Filter for those.
 type ListOpts struct {
 	// Name is the DNS Zone name and may include *-wildcards.
 	Name string `q:"name"`
@@ -29,6 +34,13 @@ type ListOpts struct {
 	DomainID string `q:"domain"`
 	// ProjectID will only return DNS Zones with the specified ProjectID.
 	ProjectID string `q:"project"`
+	// Statuses will only return DNS Zones with one of these statuses.
+	Statuses []ZoneStatus `q:"status"`
+	// Types will only return DNS Zones of one of these types.
+	Types []ZoneType `q:"type"`
+	// ChangedSince will only return DNS Zones that were changed at or after
+	// this time.
+	ChangedSince time.Time `q:"-"`
 	// Limit will limit the number of results returned per page.
 	Limit int `q:"limit"`
 }
@@ -36,7 +48,18 @@ type ListOpts struct {
 // ToZoneListQuery formats a ListOpts into a query string.
 func (opts ListOpts) ToZoneListQuery() (string, error) {
 	q, err := gophercloud.BuildQueryString(opts)
-	return q.String(), err
+	if err != nil {
+		return "", err
+	}
+	params := q.Query()
+
+	if !opts.ChangedSince.IsZero() {
+		params.Add("changed_since", opts.ChangedSince.UTC().Format(v1.TimeFormat))
+	}
+
+	q = &url.URL{RawQuery: params.Encode()}
+
+	return q.String(), nil
 }
 
 // List returns a Pager which allows you to iterate over a collection of zones.
@@ -52,6 +75,12 @@ func List(client *gophercloud.ServiceClient, opts ListOptsBuilder) pagination.Pa
 	return pagination.NewPager(client, serviceURL, v1.CreatePage())
 }
 
+// ListAll returns an iterator over all zones returned by List. Pages
+// are fetched lazily while iterating.
+func ListAll(ctx context.Context, client *gophercloud.ServiceClient, opts ListOptsBuilder) iter.Seq2[Zone, error] {
+	return v1.All[Zone](ctx, List(client, opts))
+}
+
 // Get retrieves a specific zone based on its unique ID.
 func Get(ctx context.Context, c *gophercloud.ServiceClient, id string) (r GetResult) {
 	//nolint:bodyclose // already handled by gophercloud
@@ -4,6 +4,9 @@
 package dns
 
 import (
+	"encoding/json"
+	"time"
+
 	"github.com/gophercloud/gophercloud/v2"
 	"github.com/gophercloud/gophercloud/v2/pagination"
 
@@ -22,17 +25,38 @@ type Zone struct {
 	Pool               string            `json:"pool,omitempty"`
 	PoolDescription    string            `json:"poolDescription,omitempty"`
 	TTL                int               `json:"ttl,omitempty"`
-	Status             string            `json:"status,omitempty"`
-	Action             string            `json:"action,omitempty"`
-	Type               string            `json:"type,omitempty"`
+	Status             ZoneStatus        `json:"status,omitempty"`
+	Action             ZoneAction        `json:"action,omitempty"`
+	Type               ZoneType          `json:"type,omitempty"`
 	Attributes         map[string]string `json:"attributes,omitempty"`
 	SharedWithProjects []string          `json:"sharedWithProjects,omitempty"`
 	ProjectID          string            `json:"projectId,omitempty"`
 	ProjectName        string            `json:"projectName,omitempty"`
 	DomainID           string            `json:"domainId,omitempty"`
 	DomainName         string            `json:"domainName,omitempty"`
-	CreatedAt          string            `json:"createdAt,omitempty"`
-	UpdatedAt          string            `json:"updatedAt,omitempty"`
+	// CreatedAt and UpdatedAt are the raw timestamps in v1.TimeFormat.
+	CreatedAt string `json:"createdAt,omitempty"`
+	UpdatedAt string `json:"updatedAt,omitempty"`
+	// CreatedTime and UpdatedTime are parsed from CreatedAt and UpdatedAt.
+	// They are zero if the raw value is empty or malformed.
+	CreatedTime time.Time `json:"-"`
+	UpdatedTime time.Time `json:"-"`
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (r *Zone) UnmarshalJSON(b []byte) error {
+	type tmp Zone
+	var s tmp
+	err := json.Unmarshal(b, &s)
+	if err != nil {
+		return err
+	}
+
+	*r = Zone(s)
+	r.CreatedTime = v1.ParseTime(r.CreatedAt)
+	r.UpdatedTime = v1.ParseTime(r.UpdatedAt)
+
+	return nil
 }
 
 // Extract accepts a Page struct, specifically an v1.CommonPage struct,
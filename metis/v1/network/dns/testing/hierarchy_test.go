@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package testing
+
+import (
+	"testing"
+
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+	"github.com/gophercloud/gophercloud/v2/testhelper/client"
+
+	"github.com/sapcc/gophercloud-sapcc/v2/metis/v1/network/dns"
+)
+
+func zoneNames(nodes []*dns.Node) []string {
+	result := make([]string, 0, len(nodes))
+	for _, n := range nodes {
+		result = append(result, n.Zone.Name)
+	}
+	return result
+}
+
+func TestHierarchy(t *testing.T) {
+	h := dns.NewHierarchy([]dns.Zone{
+		{UUID: "z-com", Name: "example.com.", ProjectID: "p-1", SharedWithProjects: []string{"p-3", "p-2", "p-1"}},
+		{UUID: "z-www", Name: "www.example.com.", ParentZoneID: "z-com", ProjectID: "p-2"},
+		{UUID: "z-api", Name: "api.example.com.", ParentZoneID: "z-com", ProjectID: "p-1"},
+		{UUID: "z-orphan", Name: "lost.example.org.", ParentZoneID: "z-missing"},
+		{UUID: "z-b", Name: "b.example.net.", ParentZoneID: "z-a"},
+		{UUID: "z-a", Name: "a.example.net.", ParentZoneID: "z-b"},
+		{UUID: "z-c", Name: "c.b.example.net.", ParentZoneID: "z-b"},
+	})
+
+	th.CheckDeepEquals(t, []string{"example.com."}, zoneNames(h.Roots))
+	th.CheckDeepEquals(t, []string{"api.example.com.", "www.example.com."}, zoneNames(h.Roots[0].Children))
+	th.CheckDeepEquals(t, []string{"lost.example.org."}, zoneNames(h.Orphans))
+	th.CheckDeepEquals(t, []string{"a.example.net.", "b.example.net."}, zoneNames(h.CycleRoots))
+	th.CheckDeepEquals(t, [][]string{{"z-a", "z-b"}}, h.Cycles)
+
+	n, exists := h.Zone("z-c")
+	th.AssertEquals(t, true, exists)
+	th.AssertEquals(t, "z-b", n.Parent.Zone.UUID)
+	n, _ = h.Zone("z-a")
+	th.AssertEquals(t, true, n.Parent == nil)
+
+	n, exists = h.ClosestZone("Host.WWW.example.com")
+	th.AssertEquals(t, true, exists)
+	th.AssertEquals(t, "z-www", n.Zone.UUID)
+	th.AssertEquals(t, "p-2", n.Zone.ProjectID)
+	n, exists = h.ClosestZone("mail.example.com.")
+	th.AssertEquals(t, true, exists)
+	th.AssertEquals(t, "z-com", n.Zone.UUID)
+	_, exists = h.ClosestZone("example.de")
+	th.AssertEquals(t, false, exists)
+
+	th.CheckDeepEquals(t, []string{"p-1", "p-2", "p-3"}, h.VisibleTo("z-com"))
+	th.CheckDeepEquals(t, []string{"p-2"}, h.VisibleTo("z-www"))
+	th.AssertEquals(t, 0, len(h.VisibleTo("z-unknown")))
+
+	var walked []string
+	h.Walk(func(n *dns.Node, depth int) bool {
+		walked = append(walked, n.Zone.UUID)
+		return depth == 0
+	})
+	th.CheckDeepEquals(t, []string{"z-com", "z-api", "z-www", "z-orphan", "z-a", "z-b"}, walked)
+
+	// zones in and below a cycle are reached through CycleRoots
+	walked = nil
+	h.Walk(func(n *dns.Node, _ int) bool {
+		walked = append(walked, n.Zone.UUID)
+		return true
+	})
+	th.CheckDeepEquals(t, []string{"z-com", "z-api", "z-www", "z-orphan", "z-a", "z-b", "z-c"}, walked)
+}
+
+func TestLoadHierarchy(t *testing.T) {
+	fakeServer := th.SetupHTTP()
+	defer fakeServer.Teardown()
+	HandleListDNSZonesSuccessfully(t, fakeServer)
+
+	h, err := dns.LoadHierarchy(t.Context(), client.ServiceClient(fakeServer), dns.ListOpts{Limit: 1})
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []string{"hermestest.test.com."}, zoneNames(h.Roots))
+	th.CheckDeepEquals(t, []string{"test-regression.germany.cloud.de."}, zoneNames(h.Orphans))
+}
@@ -4,7 +4,9 @@
 package testing
 
 import (
+	"encoding/json"
 	"testing"
+	"time"
 
 	th "github.com/gophercloud/gophercloud/v2/testhelper"
 	"github.com/gophercloud/gophercloud/v2/testhelper/client"
@@ -45,6 +47,8 @@ func TestGetDNSZone(t *testing.T) {
 		DomainName:  "test",
 		CreatedAt:   "2022-04-04 07:41:42",
 		UpdatedAt:   "2023-03-30 11:41:41",
+		CreatedTime: time.Date(2022, time.April, 4, 7, 41, 42, 0, time.UTC),
+		UpdatedTime: time.Date(2023, time.March, 30, 11, 41, 41, 0, time.UTC),
 	}
 
 	th.CheckDeepEquals(t, expected, actual)
@@ -85,6 +89,8 @@ func TestListDNSZones(t *testing.T) {
 			DomainName:  "test",
 			CreatedAt:   "2022-04-04 07:41:42",
 			UpdatedAt:   "2023-03-30 11:41:41",
+			CreatedTime: time.Date(2022, time.April, 4, 7, 41, 42, 0, time.UTC),
+			UpdatedTime: time.Date(2023, time.March, 30, 11, 41, 41, 0, time.UTC),
 		},
 		{
 			UUID:            "17374100fd4b4e72b94353fc1931a920",
@@ -110,8 +116,47 @@ func TestListDNSZones(t *testing.T) {
 			DomainName:  "admin",
 			CreatedAt:   "2019-10-14 14:15:18",
 			UpdatedAt:   "2023-07-07 01:01:23",
+			CreatedTime: time.Date(2019, time.October, 14, 14, 15, 18, 0, time.UTC),
+			UpdatedTime: time.Date(2023, time.July, 7, 1, 1, 23, 0, time.UTC),
 		},
 	}
 
 	th.CheckDeepEquals(t, expected, actual)
 }
+
+func TestUnmarshalZoneWithUnknownValues(t *testing.T) {
+	var zone dns.Zone
+	err := json.Unmarshal([]byte(`{"status": "FROZEN", "type": "PRIMARY", "createdAt": "yesterday"}`), &zone)
+	th.AssertNoErr(t, err)
+
+	// raw values are kept
+	th.AssertEquals(t, dns.ZoneStatus("FROZEN"), zone.Status)
+	th.AssertEquals(t, false, zone.Status.IsValid())
+	th.AssertEquals(t, dns.ZoneTypePrimary, zone.Type)
+	th.AssertEquals(t, true, zone.Action.IsValid())
+	th.AssertEquals(t, "yesterday", zone.CreatedAt)
+	th.AssertEquals(t, true, zone.CreatedTime.IsZero())
+}
+
+func TestListOpts(t *testing.T) {
+	opts := dns.ListOpts{
+		Statuses:     []dns.ZoneStatus{dns.ZoneStatusActive, dns.ZoneStatusPending},
+		Types:        []dns.ZoneType{dns.ZoneTypePrimary},
+		ChangedSince: time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	query, err := opts.ToZoneListQuery()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "?changed_since=2023-01-01+00%3A00%3A00&status=ACTIVE&status=PENDING&type=PRIMARY", query)
+}
+
+func TestFilterDNSZones(t *testing.T) {
+	zone := dns.Zone{
+		Pool:               "default",
+		SharedWithProjects: []string{"p-1"},
+	}
+	th.AssertEquals(t, true, dns.Filter{}.Matches(zone))
+	th.AssertEquals(t, true, dns.Filter{Pool: "default", SharedWithProject: "p-1"}.Matches(zone))
+	th.AssertEquals(t, false, dns.Filter{Pool: "other"}.Matches(zone))
+	th.AssertEquals(t, false, dns.Filter{SharedWithProject: "p-2"}.Matches(zone))
+}
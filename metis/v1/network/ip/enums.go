@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package ip
+
+import "slices"
+
+// Status is the status of the port that an IP address is bound to. Values
+// that are not listed here are kept as they are.
+type Status string
+
+const (
+	StatusActive Status = "ACTIVE"
+	StatusDown   Status = "DOWN"
+	StatusBuild  Status = "BUILD"
+	StatusError  Status = "ERROR"
+	StatusNA     Status = "N/A"
+)
+
+// Statuses returns all known Status values.
+func Statuses() []Status {
+	return []Status{StatusActive, StatusDown, StatusBuild, StatusError, StatusNA}
+}
+
+// IsValid returns whether the value is empty or one of the known values.
+func (v Status) IsValid() bool {
+	return v == "" || slices.Contains(Statuses(), v)
+}
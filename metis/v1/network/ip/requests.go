@@ -5,7 +5,10 @@ package ip
 
 import (
 	"context"
+	"iter"
 	"net/http"
+	"net/url"
+	"time"
 
 	"github.com/gophercloud/gophercloud/v2"
 	"github.com/gophercloud/gophercloud/v2/pagination"
@@ -19,7 +22,8 @@ type ListOptsBuilder interface {
 	ToIPAddressListQuery() (string, error)
 }
 
-// ListOpts is a structure that holds options for listing ipaddresses.
+// ListOpts is a structure that holds options for listing ipaddresses. All
+// criteria are applied by the server.
 type ListOpts struct {
 	// IPAddresses will only return ipaddresses with the specified UUIDs.
 	IPAddresses []string `q:"ip"`
@@ -29,6 +33,20 @@ type ListOpts struct {
 	DomainID string `q:"domain"`
 	// ProjectID will only return ipaddresses with the specified ProjectID.
 	ProjectID string `q:"project"`
+	// NetworkIDs will only return ipaddresses in one of these networks.
+	NetworkIDs []string `q:"network"`
+	// SubnetIDs will only return ipaddresses in one of these subnets.
+	SubnetIDs []string `q:"subnet"`
+	// PortIDs will only return ipaddresses bound to one of these ports.
+	PortIDs []string `q:"port"`
+	// DeviceOwners will only return ipaddresses whose port is owned by one
+	// of these device owners, e.g. "network:dhcp".
+	DeviceOwners []string `q:"device_owner"`
+	// Statuses will only return ipaddresses with one of these statuses.
+	Statuses []Status `q:"status"`
+	// ChangedSince will only return ipaddresses that were changed at or
+	// after this time.
+	ChangedSince time.Time `q:"-"`
 	// Limit will limit the number of results returned per page.
 	Limit int `q:"limit"`
 }
@@ -36,7 +54,18 @@ type ListOpts struct {
 // ToIPAddressListQuery formats a ListOpts into a query string.
 func (opts ListOpts) ToIPAddressListQuery() (string, error) {
 	q, err := gophercloud.BuildQueryString(opts)
-	return q.String(), err
+	if err != nil {
+		return "", err
+	}
+	params := q.Query()
+
+	if !opts.ChangedSince.IsZero() {
+		params.Add("changed_since", opts.ChangedSince.UTC().Format(v1.TimeFormat))
+	}
+
+	q = &url.URL{RawQuery: params.Encode()}
+
+	return q.String(), nil
 }
 
 // List returns a Pager which allows you to iterate over a collection of ipadresses.
@@ -52,6 +81,12 @@ func List(client *gophercloud.ServiceClient, opts ListOptsBuilder) pagination.Pa
 	return pagination.NewPager(client, serviceURL, v1.CreatePage())
 }
 
+// ListAll returns an iterator over all IP addresses returned by List. Pages
+// are fetched lazily while iterating.
+func ListAll(ctx context.Context, client *gophercloud.ServiceClient, opts ListOptsBuilder) iter.Seq2[IPAddress, error] {
+	return v1.All[IPAddress](ctx, List(client, opts))
+}
+
 // Get retrieves a specific ipaddress.
 func Get(ctx context.Context, c *gophercloud.ServiceClient, ipaddress string) (r GetResult) {
 	//nolint:bodyclose // already handled by gophercloud
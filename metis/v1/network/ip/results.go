@@ -4,6 +4,9 @@
 package ip
 
 import (
+	"encoding/json"
+	"time"
+
 	"github.com/gophercloud/gophercloud/v2"
 	"github.com/gophercloud/gophercloud/v2/pagination"
 
@@ -17,7 +20,7 @@ type IPAddress struct {
 	IP          string `json:"ipaddress"`
 	PortUUID    string `json:"port"`
 	Description string `json:"description,omitempty"`
-	Status      string `json:"status,omitempty"`
+	Status      Status `json:"status,omitempty"`
 	DeviceID    string `json:"deviceID,omitempty"`
 	DeviceOwner string `json:"deviceOwner,omitempty"`
 	FixedPortID string `json:"fixedPortID,omitempty"`
@@ -30,8 +33,29 @@ type IPAddress struct {
 	DomainName  string `json:"domainName,omitempty"`
 	ProjectID   string `json:"projectID,omitempty"`
 	ProjectName string `json:"projectName,omitempty"`
+	// Created and LastChanged are the raw timestamps in v1.TimeFormat.
 	Created     string `json:"created,omitempty"`
 	LastChanged string `json:"lastChanged,omitempty"`
+	// CreatedTime and LastChangedTime are parsed from Created and
+	// LastChanged. They are zero if the raw value is empty or malformed.
+	CreatedTime     time.Time `json:"-"`
+	LastChangedTime time.Time `json:"-"`
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (r *IPAddress) UnmarshalJSON(b []byte) error {
+	type tmp IPAddress
+	var s tmp
+	err := json.Unmarshal(b, &s)
+	if err != nil {
+		return err
+	}
+
+	*r = IPAddress(s)
+	r.CreatedTime = v1.ParseTime(r.Created)
+	r.LastChangedTime = v1.ParseTime(r.LastChanged)
+
+	return nil
 }
 
 // Extract accepts a Page struct, specifically an v1.CommonPage struct,
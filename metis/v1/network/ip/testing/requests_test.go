@@ -5,6 +5,7 @@ package testing
 
 import (
 	"testing"
+	"time"
 
 	th "github.com/gophercloud/gophercloud/v2/testhelper"
 	"github.com/gophercloud/gophercloud/v2/testhelper/client"
@@ -21,22 +22,24 @@ func TestGetProject(t *testing.T) {
 	th.AssertNoErr(t, err)
 
 	expected := &ip.IPAddress{
-		IP:          "10.216.24.194",
-		PortUUID:    "9cf53dfa-8a72-1337-bf69-523d11ffccb9",
-		Description: "",
-		Status:      "ACTIVE",
-		DeviceID:    "dhcp5d784bae-4201-530e-90df-393914f8601b-1563904c-ac3d-4281-994a-676d9a1716c6",
-		DeviceOwner: "network:dhcp",
-		NetworkID:   "1563904c-ac3d-1337-994a-676d9a1716c6",
-		NetworkName: "network-name",
-		SubnetID:    "e6f6ff0c-42fa-1337-9e78-2a8405fed887",
-		SubnetName:  "subnet-name",
-		DomainID:    "666da95112694b37b3efb0913de31337",
-		DomainName:  "admin",
-		ProjectID:   "0420083ad7d145dc9fdb9ccdb59ad5b6",
-		ProjectName: "admin-net-infra",
-		Created:     "2019-08-12 09:22:54",
-		LastChanged: "2023-08-10 14:26:51",
+		IP:              "10.216.24.194",
+		PortUUID:        "9cf53dfa-8a72-1337-bf69-523d11ffccb9",
+		Description:     "",
+		Status:          "ACTIVE",
+		DeviceID:        "dhcp5d784bae-4201-530e-90df-393914f8601b-1563904c-ac3d-4281-994a-676d9a1716c6",
+		DeviceOwner:     "network:dhcp",
+		NetworkID:       "1563904c-ac3d-1337-994a-676d9a1716c6",
+		NetworkName:     "network-name",
+		SubnetID:        "e6f6ff0c-42fa-1337-9e78-2a8405fed887",
+		SubnetName:      "subnet-name",
+		DomainID:        "666da95112694b37b3efb0913de31337",
+		DomainName:      "admin",
+		ProjectID:       "0420083ad7d145dc9fdb9ccdb59ad5b6",
+		ProjectName:     "admin-net-infra",
+		Created:         "2019-08-12 09:22:54",
+		LastChanged:     "2023-08-10 14:26:51",
+		CreatedTime:     time.Date(2019, time.August, 12, 9, 22, 54, 0, time.UTC),
+		LastChangedTime: time.Date(2023, time.August, 10, 14, 26, 51, 0, time.UTC),
 	}
 
 	th.CheckDeepEquals(t, expected, actual)
@@ -56,40 +59,86 @@ func TestListProjects(t *testing.T) {
 
 	expected := []ip.IPAddress{
 		{
-			IP:          "127.0.0.1",
-			PortUUID:    "9cf53dfa-8a72-1337-bf69-523d11ffccb9",
-			Description: "test",
-			Status:      "ACTIVE",
-			DeviceID:    "dhcp5d784bae-4201-530e-90df-393914f8601b-1563904c-ac3d-4281-994a-676d9a1716c6",
-			DeviceOwner: "network:dhcp",
-			NetworkID:   "1563904c-ac3d-1337-994a-676d9a1716c6",
-			NetworkName: "network-name",
-			SubnetID:    "e6f6ff0c-42fa-1337-9e78-2a8405fed887",
-			SubnetName:  "subnet-name",
-			DomainID:    "666da95112694b37b3efb0913de31337",
-			DomainName:  "admin",
-			ProjectID:   "0420083ad7d145dc9fdb9ccdb59ad5b6",
-			ProjectName: "admin-net-infra",
-			Created:     "2019-08-12 09:22:54",
-			LastChanged: "2023-08-10 14:26:51",
+			IP:              "127.0.0.1",
+			PortUUID:        "9cf53dfa-8a72-1337-bf69-523d11ffccb9",
+			Description:     "test",
+			Status:          "ACTIVE",
+			DeviceID:        "dhcp5d784bae-4201-530e-90df-393914f8601b-1563904c-ac3d-4281-994a-676d9a1716c6",
+			DeviceOwner:     "network:dhcp",
+			NetworkID:       "1563904c-ac3d-1337-994a-676d9a1716c6",
+			NetworkName:     "network-name",
+			SubnetID:        "e6f6ff0c-42fa-1337-9e78-2a8405fed887",
+			SubnetName:      "subnet-name",
+			DomainID:        "666da95112694b37b3efb0913de31337",
+			DomainName:      "admin",
+			ProjectID:       "0420083ad7d145dc9fdb9ccdb59ad5b6",
+			ProjectName:     "admin-net-infra",
+			Created:         "2019-08-12 09:22:54",
+			LastChanged:     "2023-08-10 14:26:51",
+			CreatedTime:     time.Date(2019, time.August, 12, 9, 22, 54, 0, time.UTC),
+			LastChangedTime: time.Date(2023, time.August, 10, 14, 26, 51, 0, time.UTC),
 		}, {
-			IP:          "192.0.0.1",
-			PortUUID:    "9cf53dfa-8a72-1337-bf69-523d11ffccb9",
-			Description: "dummy",
-			Status:      "ACTIVE",
-			DeviceID:    "dhcp5d784bae-4201-530e-90df-393914f8601b-1563904c-ac3d-4281-994a-676d9a1716c6",
-			DeviceOwner: "network:dhcp",
-			NetworkID:   "1563904c-ac3d-1337-994a-676d9a1716c6",
-			NetworkName: "network-name",
-			SubnetID:    "e6f6ff0c-42fa-1337-9e78-2a8405fed887",
-			SubnetName:  "subnet-name",
-			DomainID:    "666da95112694b37b3efb0913de31337",
-			DomainName:  "admin",
-			ProjectID:   "0420083ad7d145dc9fdb9ccdb59ad5b6",
-			ProjectName: "admin-net-infra",
-			Created:     "2019-08-12 09:22:54",
-			LastChanged: "2023-08-10 14:26:51",
+			IP:              "192.0.0.1",
+			PortUUID:        "9cf53dfa-8a72-1337-bf69-523d11ffccb9",
+			Description:     "dummy",
+			Status:          "ACTIVE",
+			DeviceID:        "dhcp5d784bae-4201-530e-90df-393914f8601b-1563904c-ac3d-4281-994a-676d9a1716c6",
+			DeviceOwner:     "network:dhcp",
+			NetworkID:       "1563904c-ac3d-1337-994a-676d9a1716c6",
+			NetworkName:     "network-name",
+			SubnetID:        "e6f6ff0c-42fa-1337-9e78-2a8405fed887",
+			SubnetName:      "subnet-name",
+			DomainID:        "666da95112694b37b3efb0913de31337",
+			DomainName:      "admin",
+			ProjectID:       "0420083ad7d145dc9fdb9ccdb59ad5b6",
+			ProjectName:     "admin-net-infra",
+			Created:         "2019-08-12 09:22:54",
+			LastChanged:     "2023-08-10 14:26:51",
+			CreatedTime:     time.Date(2019, time.August, 12, 9, 22, 54, 0, time.UTC),
+			LastChangedTime: time.Date(2023, time.August, 10, 14, 26, 51, 0, time.UTC),
 		}}
 
 	th.CheckDeepEquals(t, expected, actual)
 }
+
+func TestListAllIPAddresses(t *testing.T) {
+	fakeServer := th.SetupHTTP()
+	defer fakeServer.Teardown()
+	HandleListIPAddressesSuccessfully(t, fakeServer)
+
+	opts := ip.ListOpts{Limit: 1}
+
+	var actual []string
+	for addr, err := range ip.ListAll(t.Context(), client.ServiceClient(fakeServer), opts) {
+		th.AssertNoErr(t, err)
+		actual = append(actual, addr.IP)
+	}
+	th.CheckDeepEquals(t, []string{"127.0.0.1", "192.0.0.1"}, actual)
+}
+
+func TestListAllError(t *testing.T) {
+	fakeServer := th.SetupHTTP()
+	defer fakeServer.Teardown()
+
+	count := 0
+	for _, err := range ip.ListAll(t.Context(), client.ServiceClient(fakeServer), nil) {
+		count++
+		th.AssertErr(t, err)
+	}
+	th.AssertEquals(t, 1, count)
+}
+
+func TestListOpts(t *testing.T) {
+	opts := ip.ListOpts{
+		NetworkIDs:   []string{"net-1", "net-2"},
+		SubnetIDs:    []string{"subnet-1"},
+		PortIDs:      []string{"port-1"},
+		DeviceOwners: []string{"network:dhcp"},
+		Statuses:     []ip.Status{ip.StatusActive},
+		ChangedSince: time.Date(2023, time.August, 1, 12, 30, 0, 0, time.UTC),
+	}
+
+	query, err := opts.ToIPAddressListQuery()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "?changed_since=2023-08-01+12%3A30%3A00&device_owner=network%3Adhcp&network=net-1&network=net-2&port=port-1&status=ACTIVE&subnet=subnet-1", query)
+}
@@ -0,0 +1,316 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ownership resolves who is accountable for an IP address, e.g. for
+// abuse handling.
+//
+// An IP address is looked up with network/ip.Get. Its project is read with
+// identity/projects.Get, which provides the CBR masterdata contacts and the
+// users of the project, and the domain of the project is read with
+// identity/domains.Get, which provides the billing metadata of the domain.
+package ownership
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/gophercloud/gophercloud/v2"
+
+	"github.com/sapcc/gophercloud-sapcc/v2/internal/parallel"
+	"github.com/sapcc/gophercloud-sapcc/v2/metis/v1/identity/domains"
+	"github.com/sapcc/gophercloud-sapcc/v2/metis/v1/identity/projects"
+	"github.com/sapcc/gophercloud-sapcc/v2/metis/v1/network/ip"
+)
+
+// ContactRole describes why a person is a contact for an IP address.
+type ContactRole string
+
+const (
+	ContactRoleProjectPrimaryContact            ContactRole = "project_primary_contact"
+	ContactRoleProjectOperator                  ContactRole = "project_operator"
+	ContactRoleProjectInventoryRole             ContactRole = "project_inventory_role"
+	ContactRoleProjectInfrastructureCoordinator ContactRole = "project_infrastructure_coordinator"
+	ContactRoleDomainPrimaryContact             ContactRole = "domain_primary_contact"
+)
+
+// Contact is a person or group that is accountable for an IP address.
+type Contact struct {
+	Role   ContactRole `json:"role"`
+	UserID string      `json:"user_id,omitempty"`
+	Email  string      `json:"email,omitempty"`
+}
+
+// Ownership is the ownership record of an IP address.
+type Ownership struct {
+	IP ip.IPAddress `json:"ip"`
+	// Project and Domain are nil if the IP address is not assigned to a
+	// project or domain.
+	Project *projects.Project `json:"project,omitempty"`
+	Domain  *domains.Domain   `json:"domain,omitempty"`
+	// Contacts lists the contacts from the project's CBR masterdata, followed
+	// by the primary contact of the domain. Contacts without user ID and
+	// email are left out.
+	Contacts []Contact `json:"contacts"`
+	// Users are the users of the project.
+	Users []projects.User `json:"users"`
+}
+
+// Result is an element of the result of ResolveBatch.
+type Result struct {
+	Ownership
+	// Err is set if the ownership of this IP address could not be resolved
+	// completely. Ownership contains everything that could be resolved.
+	Err error `json:"-"`
+}
+
+// DefaultBatchConcurrency is the number of IP addresses that ResolveBatch
+// resolves in parallel when Resolver.Concurrency is not set.
+const DefaultBatchConcurrency = 4
+
+// Resolver resolves the ownership of IP addresses. Projects and domains are
+// cached for the lifetime of the Resolver, so a Resolver should be
+// short-lived, e.g. one per batch. It is safe for concurrent use.
+type Resolver struct {
+	// Concurrency limits the number of IP addresses that ResolveBatch
+	// resolves in parallel.
+	Concurrency int
+
+	client *gophercloud.ServiceClient
+
+	mutex    sync.Mutex
+	projects map[string]*cacheEntry[projects.Project]
+	domains  map[string]*cacheEntry[domains.Domain]
+}
+
+// NewResolver returns a Resolver that reads from Metis with the given client.
+func NewResolver(client *gophercloud.ServiceClient) *Resolver {
+	return &Resolver{
+		client:   client,
+		projects: make(map[string]*cacheEntry[projects.Project]),
+		domains:  make(map[string]*cacheEntry[domains.Domain]),
+	}
+}
+
+// Resolve returns the ownership of a single IP address.
+func (r *Resolver) Resolve(ctx context.Context, address string) (Ownership, error) {
+	addr, err := netip.ParseAddr(address)
+	if err != nil {
+		return Ownership{}, fmt.Errorf("invalid IP address %q: %w", address, err)
+	}
+	ipAddress, err := ip.Get(ctx, r.client, addr.String()).Extract()
+	if err != nil {
+		return Ownership{}, err
+	}
+	return r.ResolveIPAddress(ctx, *ipAddress)
+}
+
+// ResolveIPAddress returns the ownership of an IP address that was already
+// read from Metis, e.g. with ip.List.
+func (r *Resolver) ResolveIPAddress(ctx context.Context, ipAddress ip.IPAddress) (Ownership, error) {
+	result := Ownership{IP: ipAddress}
+
+	domainID := ipAddress.DomainID
+	if ipAddress.ProjectID != "" {
+		project, err := r.project(ctx, ipAddress.ProjectID)
+		if err != nil {
+			return result, fmt.Errorf("cannot read project %s of IP address %s: %w", ipAddress.ProjectID, ipAddress.IP, err)
+		}
+		result.Project = &project
+		result.Users = project.Users
+
+		md := project.CBRMasterdata
+		result.addContact(ContactRoleProjectPrimaryContact, md.PrimaryContactUserID, md.PrimaryContactEmail)
+		result.addContact(ContactRoleProjectOperator, md.OperatorUserID, md.OperatorEmail)
+		result.addContact(ContactRoleProjectInventoryRole, md.InventoryRoleUserID, md.InventoryRoleEmail)
+		result.addContact(ContactRoleProjectInfrastructureCoordinator, md.InfrastructureCoordinatorUserID, md.InfrastructureCoordinatorEmail)
+		if project.DomainUUID != "" {
+			domainID = project.DomainUUID
+		}
+	}
+
+	if domainID != "" {
+		domain, err := r.domain(ctx, domainID)
+		if err != nil {
+			return result, fmt.Errorf("cannot read domain %s of IP address %s: %w", domainID, ipAddress.IP, err)
+		}
+		result.Domain = &domain
+		md := domain.BillingMetadata
+		result.addContact(ContactRoleDomainPrimaryContact, md.PrimaryContactUserID, md.PrimaryContactEmail)
+	}
+	return result, nil
+}
+
+// ResolveBatch resolves the ownership of all IP addresses that are given
+// directly or contained in one of the given CIDRs. IP addresses in CIDRs are
+// listed with a single ip.List call; addresses that Metis does not know are
+// not reported. The IP addresses are resolved in parallel. Results are sorted
+// by IP address.
+//
+// Errors for individual IP addresses are reported in the respective result;
+// the returned error is only set if an input is malformed, listing fails or
+// the context was cancelled.
+func (r *Resolver) ResolveBatch(ctx context.Context, inputs []string) ([]Result, error) {
+	var (
+		addresses []string
+		cidrs     []string
+	)
+	for _, input := range inputs {
+		if prefix, err := netip.ParsePrefix(input); err == nil {
+			cidrs = append(cidrs, prefix.Masked().String())
+			continue
+		}
+		addr, err := netip.ParseAddr(input)
+		if err != nil {
+			return nil, fmt.Errorf("invalid IP address or CIDR: %q", input)
+		}
+		addresses = append(addresses, addr.String())
+	}
+
+	var (
+		pending []batchItem
+		seen    = make(map[string]bool)
+	)
+	if len(cidrs) > 0 {
+		for ipAddress, err := range ip.ListAll(ctx, r.client, ip.ListOpts{CIDR: cidrs}) {
+			if err != nil {
+				return nil, err
+			}
+			// normalize the address, so that it matches the given addresses
+			address := ipAddress.IP
+			if addr, parseErr := netip.ParseAddr(address); parseErr == nil {
+				address = addr.String()
+			}
+			if seen[address] {
+				continue
+			}
+			seen[address] = true
+			pending = append(pending, batchItem{address: address, listed: &ipAddress})
+		}
+	}
+	for _, address := range addresses {
+		if seen[address] {
+			continue
+		}
+		seen[address] = true
+		pending = append(pending, batchItem{address: address})
+	}
+
+	concurrency := r.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultBatchConcurrency
+	}
+	var result []Result
+	resolve := func(item batchItem) Result {
+		return r.resolveBatchItem(ctx, item)
+	}
+	err := parallel.ForEach(ctx, pending, concurrency, resolve, func(_ batchItem, res Result) {
+		result = append(result, res)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(result, func(lhs, rhs Result) int {
+		return compareAddrs(lhs.IP.IP, rhs.IP.IP)
+	})
+	return result, nil
+}
+
+// batchItem is an IP address in ResolveBatch. If it was listed from a CIDR,
+// listed holds the record from Metis.
+type batchItem struct {
+	address string
+	listed  *ip.IPAddress
+}
+
+func (r *Resolver) resolveBatchItem(ctx context.Context, item batchItem) Result {
+	if item.listed != nil {
+		o, err := r.ResolveIPAddress(ctx, *item.listed)
+		return Result{Ownership: o, Err: err}
+	}
+	o, err := r.Resolve(ctx, item.address)
+	if o.IP.IP == "" {
+		o.IP.IP = item.address
+	}
+	return Result{Ownership: o, Err: err}
+}
+
+func (o *Ownership) addContact(role ContactRole, userID, email string) {
+	if userID == "" && email == "" {
+		return
+	}
+	o.Contacts = append(o.Contacts, Contact{Role: role, UserID: userID, Email: email})
+}
+
+// compareAddrs orders IP addresses numerically. Malformed addresses are
+// sorted last, by their string value.
+func compareAddrs(lhs, rhs string) int {
+	lhsAddr, lhsErr := netip.ParseAddr(lhs)
+	rhsAddr, rhsErr := netip.ParseAddr(rhs)
+	switch {
+	case lhsErr == nil && rhsErr == nil:
+		return lhsAddr.Compare(rhsAddr)
+	case lhsErr == nil:
+		return -1
+	case rhsErr == nil:
+		return 1
+	default:
+		return strings.Compare(lhs, rhs)
+	}
+}
+
+// project returns the project with the given ID from the cache, or reads it.
+func (r *Resolver) project(ctx context.Context, projectID string) (projects.Project, error) {
+	return load(&r.mutex, r.projects, projectID, func() (*projects.Project, error) {
+		return projects.Get(ctx, r.client, projectID).Extract()
+	})
+}
+
+// domain returns the domain with the given ID from the cache, or reads it.
+func (r *Resolver) domain(ctx context.Context, domainID string) (domains.Domain, error) {
+	return load(&r.mutex, r.domains, domainID, func() (*domains.Domain, error) {
+		return domains.Get(ctx, r.client, domainID).Extract()
+	})
+}
+
+// cacheEntry is a project or domain in the cache of a Resolver. The value
+// and error are set before ready is closed.
+type cacheEntry[T any] struct {
+	ready chan struct{}
+	value T
+	err   error
+}
+
+// load returns the value with the given ID from the cache, or reads it with
+// fetch. Concurrent calls for the same ID wait for the first read instead of
+// reading again. Failed reads are not cached.
+func load[T any](mutex *sync.Mutex, cache map[string]*cacheEntry[T], id string, fetch func() (*T, error)) (T, error) {
+	mutex.Lock()
+	entry, exists := cache[id]
+	if !exists {
+		entry = &cacheEntry[T]{ready: make(chan struct{})}
+		cache[id] = entry
+	}
+	mutex.Unlock()
+	if exists {
+		<-entry.ready
+		return entry.value, entry.err
+	}
+
+	value, err := fetch()
+	if err == nil {
+		entry.value = *value
+	}
+	entry.err = err
+	close(entry.ready)
+	if err != nil {
+		mutex.Lock()
+		delete(cache, id)
+		mutex.Unlock()
+	}
+	return entry.value, err
+}
@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package testing
+
+const IPGetResponse = `
+{
+  "apiVersion": "1.0",
+  "data": {
+    "kind": "ipaddress",
+    "item": {
+      "ipaddress": "10.0.0.1",
+      "port": "port-1",
+      "status": "ACTIVE",
+      "domainID": "d-1",
+      "projectID": "p-1"
+    }
+  }
+}
+`
+
+const IPListResponse = `
+{
+  "apiVersion": "1.0",
+  "data": {
+    "kind": "ipaddress",
+    "items": [
+      {
+        "ipaddress": "10.0.0.2",
+        "port": "port-2",
+        "status": "DOWN",
+        "domainID": "d-1",
+        "projectID": "p-1"
+      },
+      {
+        "ipaddress": "10.0.0.1",
+        "port": "port-1",
+        "status": "ACTIVE",
+        "domainID": "d-1",
+        "projectID": "p-1"
+      }
+    ]
+  }
+}
+`
+
+const ProjectGetResponse = `
+{
+  "apiVersion": "1.0",
+  "data": {
+    "kind": "project",
+    "item": {
+      "name": "project1",
+      "uuid": "p-1",
+      "domainName": "domain1",
+      "domainUUID": "d-1",
+      "cbrMasterdata": {
+        "primaryContactUserID": "u-1",
+        "primaryContactEmail": "primary@example.com",
+        "operatorEmail": "operators@example.com",
+        "externalCertifications": {}
+      },
+      "users": [
+        {"uuid": "u-1", "name": "Jane Doe", "email": "primary@example.com"}
+      ]
+    }
+  }
+}
+`
+
+const DomainGetResponse = `
+{
+  "apiVersion": "1.0",
+  "data": {
+    "kind": "domain",
+    "item": {
+      "name": "domain1",
+      "uuid": "d-1",
+      "cbrMasterdata": {
+        "primaryContactUserID": "u-2",
+        "primaryContactEmail": "domain@example.com"
+      }
+    }
+  }
+}
+`
+
+const IPv6ListResponse = `
+{
+  "apiVersion": "1.0",
+  "data": {
+    "kind": "ipaddress",
+    "items": [
+      {
+        "ipaddress": "2001:DB8:0:0::1",
+        "port": "port-3",
+        "status": "ACTIVE"
+      }
+    ]
+  }
+}
+`
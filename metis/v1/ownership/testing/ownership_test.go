@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/v2"
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+	"github.com/gophercloud/gophercloud/v2/testhelper/client"
+
+	"github.com/sapcc/gophercloud-sapcc/v2/metis/v1/ownership"
+)
+
+// handleMetis registers the Metis endpoints and returns the number of
+// requests per path. Read it only after all requests have finished.
+func handleMetis(t *testing.T, fakeServer th.FakeServer) map[string]int {
+	var mutex sync.Mutex
+	requests := make(map[string]int)
+	responses := map[string]string{
+		"/network/ip/10.0.0.1":  IPGetResponse,
+		"/identity/project/p-1": ProjectGetResponse,
+		"/identity/domain/d-1":  DomainGetResponse,
+	}
+	for path, body := range responses {
+		fakeServer.Mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			th.TestMethod(t, r, http.MethodGet)
+			th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+			mutex.Lock()
+			requests[path]++
+			mutex.Unlock()
+
+			w.Header().Add("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, body)
+		})
+	}
+	fakeServer.Mux.HandleFunc("/network/ip", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, http.MethodGet)
+		th.TestFormValues(t, r, map[string]string{"cidr": "10.0.0.0/30"})
+		mutex.Lock()
+		requests[r.URL.Path]++
+		mutex.Unlock()
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, IPListResponse)
+	})
+	return requests
+}
+
+func TestResolve(t *testing.T) {
+	fakeServer := th.SetupHTTP()
+	defer fakeServer.Teardown()
+	handleMetis(t, fakeServer)
+
+	actual, err := ownership.NewResolver(client.ServiceClient(fakeServer)).Resolve(t.Context(), "10.0.0.1")
+	th.AssertNoErr(t, err)
+
+	th.AssertEquals(t, "10.0.0.1", actual.IP.IP)
+	th.AssertEquals(t, "project1", actual.Project.Name)
+	th.AssertEquals(t, "domain1", actual.Domain.Name)
+	th.CheckDeepEquals(t, []ownership.Contact{
+		{Role: ownership.ContactRoleProjectPrimaryContact, UserID: "u-1", Email: "primary@example.com"},
+		{Role: ownership.ContactRoleProjectOperator, Email: "operators@example.com"},
+		{Role: ownership.ContactRoleDomainPrimaryContact, UserID: "u-2", Email: "domain@example.com"},
+	}, actual.Contacts)
+	th.AssertEquals(t, 1, len(actual.Users))
+	th.AssertEquals(t, "Jane Doe", actual.Users[0].Name)
+
+	_, err = ownership.NewResolver(client.ServiceClient(fakeServer)).Resolve(t.Context(), "10.0.0")
+	th.AssertErr(t, err)
+}
+
+func TestResolveBatch(t *testing.T) {
+	fakeServer := th.SetupHTTP()
+	defer fakeServer.Teardown()
+	requests := handleMetis(t, fakeServer)
+
+	resolver := ownership.NewResolver(client.ServiceClient(fakeServer))
+	results, err := resolver.ResolveBatch(t.Context(), []string{"10.0.0.9", "10.0.0.1/30", "10.0.0.1"})
+	th.AssertNoErr(t, err)
+
+	th.AssertEquals(t, 3, len(results))
+	th.AssertEquals(t, "10.0.0.1", results[0].IP.IP)
+	th.AssertNoErr(t, results[0].Err)
+	th.AssertEquals(t, "10.0.0.2", results[1].IP.IP)
+	th.AssertNoErr(t, results[1].Err)
+	th.AssertEquals(t, "d-1", results[1].Domain.ID)
+	// unknown to Metis
+	th.AssertEquals(t, "10.0.0.9", results[2].IP.IP)
+	th.AssertEquals(t, true, gophercloud.ResponseCodeIs(results[2].Err, http.StatusNotFound))
+
+	// addresses from the CIDR are not read again, projects and domains are
+	// cached
+	th.CheckDeepEquals(t, map[string]int{
+		"/network/ip":           1,
+		"/identity/project/p-1": 1,
+		"/identity/domain/d-1":  1,
+	}, requests)
+
+	_, err = resolver.ResolveBatch(t.Context(), []string{"example.com"})
+	th.AssertErr(t, err)
+}
+
+func TestResolveBatchNormalizesListedAddresses(t *testing.T) {
+	fakeServer := th.SetupHTTP()
+	defer fakeServer.Teardown()
+
+	fakeServer.Mux.HandleFunc("/network/ip", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, http.MethodGet)
+		th.TestFormValues(t, r, map[string]string{"cidr": "2001:db8::/126"})
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, IPv6ListResponse)
+	})
+
+	// the address is listed in a non-canonical form, so it must not be read
+	// again with ip.Get (which has no handler here)
+	resolver := ownership.NewResolver(client.ServiceClient(fakeServer))
+	results, err := resolver.ResolveBatch(t.Context(), []string{"2001:db8::/126", "2001:db8::1"})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, len(results))
+	th.AssertNoErr(t, results[0].Err)
+	th.AssertEquals(t, "port-3", results[0].IP.PortUUID)
+}
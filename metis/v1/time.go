@@ -0,0 +1,21 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import "time"
+
+// TimeFormat is the format of timestamps in Metis responses. Timestamps are
+// in UTC.
+const TimeFormat = time.DateTime
+
+// ParseTime parses a timestamp in TimeFormat. It returns the zero time if the
+// value is empty or malformed, so that unexpected values in a response do not
+// prevent decoding the rest of it. The raw value is kept in the models.
+func ParseTime(value string) time.Time {
+	t, err := time.ParseInLocation(TimeFormat, value, time.UTC)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}